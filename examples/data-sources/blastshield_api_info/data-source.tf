@@ -0,0 +1,8 @@
+data "blastshield_api_info" "current" {}
+
+check "license" {
+  assert {
+    condition     = data.blastshield_api_info.current.license.valid
+    error_message = "The Blastshield license is not valid."
+  }
+}
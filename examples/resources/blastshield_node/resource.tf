@@ -3,8 +3,23 @@ resource "blastshield_node" "gateway" {
   node_type     = "G" # Gateway
   endpoint_mode = "N" # NAT addressing mode (required for gateways)
 
+  settings = {
+    router_nat = true
+
+    endpoint_vlans = [
+      { id = 100, address = "10.100.0.1/24" },
+    ]
+  }
+
   tags = {
     environment = "production"
     region      = "us-east-1"
   }
 }
+
+check "gateway_online" {
+  assert {
+    condition     = blastshield_node.gateway.status.online
+    error_message = "The us-east-gateway node is offline."
+  }
+}
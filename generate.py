@@ -21,6 +21,7 @@ Uses Jinja2 templates for code generation.
 """
 
 import argparse
+import hashlib
 import json
 import os
 import re
@@ -57,6 +58,13 @@ NULLABLE_REQUIRED_FIELDS = {
     "Endpoint": ["address"],
 }
 
+# Fields renamed by an API version, keyed by that version and the resource name,
+# mapping the old JSON field name to the new one. Retyped fields are found by
+# comparing specs, but a rename looks like a removal and an addition.
+RENAMED_FIELDS = {
+    # "1.14.0": {"Node": {"old_name": "new_name"}},
+}
+
 
 @dataclass
 class FieldInfo:
@@ -76,7 +84,10 @@ class FieldInfo:
     is_nested: bool = False
     nested_fields: list = field(default_factory=list)
     nested_ref: str = ""
+    helper_name: str = ""  # Prefix of the generated helpers of a nested object, e.g. endpointVLAN
     description: str = ""
+    deprecated: bool = False  # Flagged deprecated: true in the spec
+    deprecation_message: str = ""
 
 
 @dataclass
@@ -105,6 +116,20 @@ class ResourceInfo:
     has_groups: bool = False
     store_post_response: bool = False
     post_id_field: str = "id"
+    schema_version: int = 0  # Bumped by every API version that renames or retypes an attribute
+    schema_changes: list = field(default_factory=list)  # SchemaChange per schema version
+    attributes: list = field(default_factory=list)  # Sorted resource schema attribute names
+    sub_endpoints: list = field(default_factory=list)  # Sorted paths below an entity, e.g. /groups
+    deprecated: bool = False  # An operation of the base path is flagged deprecated: true
+    deprecation_message: str = ""
+
+
+@dataclass
+class SchemaChange:
+    version: int  # Schema version that made the change
+    api_version: str  # API version that introduced it
+    renamed: dict  # Old attribute name -> new attribute name
+    retyped: list  # Attributes, by new name, whose type changed
 
 
 def to_go_name(name: str) -> str:
@@ -143,6 +168,7 @@ def parse_openapi_type(prop: dict, name: str, schemas: dict) -> FieldInfo:
         tf_type="String",
         tf_element_type="",
         description=prop.get("description", ""),
+        deprecated=prop.get("deprecated", False),
     )
 
     prop_type = prop.get("type")
@@ -165,6 +191,7 @@ def parse_openapi_type(prop: dict, name: str, schemas: dict) -> FieldInfo:
                 field_info.description = ref_schema.get("description", "")
                 return field_info
             field_info.nested_ref = ref_name
+            field_info.helper_name = ref_name[0].lower() + ref_name[1:]
             field_info.is_nested = True
             field_info.go_type = f"*{ref_name}" if field_info.is_pointer else ref_name
             field_info.tf_type = "Object"
@@ -217,6 +244,7 @@ def parse_openapi_type(prop: dict, name: str, schemas: dict) -> FieldInfo:
                     field_info.tf_element_type = "types.Int64Type"
                 else:
                     field_info.nested_ref = ref_name
+                    field_info.helper_name = ref_name[0].lower() + ref_name[1:]
                     field_info.is_nested = True
                     field_info.go_type = f"[]{ref_name}"
                     field_info.tf_element_type = ref_name
@@ -263,8 +291,6 @@ def parse_schema_fields(spec: dict, schema_name: str) -> list[FieldInfo]:
     required = schema.get("required", [])
 
     for prop_name, prop_def in properties.items():
-        if prop_name in ("status", "settings"):
-            continue
         field_info = parse_openapi_type(prop_def, prop_name, schemas)
         field_info.required = prop_name in required
         fields.append(field_info)
@@ -430,12 +456,159 @@ def find_resources(spec: dict) -> list[ResourceInfo]:
             has_groups=has_groups,
             store_post_response=store_post_response,
             post_id_field=post_id_field,
+            deprecated=any(m.get("deprecated", False) for m in methods.values()),
         )
         resources.append(resource)
 
     return resources
 
 
+def version_key(version: str) -> tuple:
+    """Sort key for API versions such as 1.13.0."""
+    return tuple(int(part) for part in re.findall(r"\d+", version))
+
+
+def attribute_type(f: FieldInfo) -> str:
+    """The Terraform type of a field's attribute, as a comparable string."""
+    if f.is_nested:
+        nested = ",".join(f"{nf.tf_name}:{attribute_type(nf)}" for nf in sorted(f.nested_fields, key=lambda nf: nf.tf_name))
+        return f"Set[Object{{{nested}}}]" if f.is_list else f"Object{{{nested}}}"
+    if f.is_list:
+        return f"Set[{f.tf_element_type}]"
+    if f.is_map:
+        return f"Map[{f.tf_element_type}]"
+    return f.tf_type
+
+
+def attribute_types(resource: ResourceInfo) -> dict:
+    """The types of the resource schema's attributes generated from the spec."""
+    return {
+        f.tf_name: attribute_type(f)
+        for f in resource.fields
+        if (f.is_nested and f.nested_fields) or not f.is_nested
+    }
+
+
+def load_sibling_specs(spec_path: str) -> list:
+    """Load the specs of the other API versions found next to spec_path, oldest first."""
+    spec_dir = os.path.dirname(spec_path) or "."
+    specs = []
+    for name in os.listdir(spec_dir):
+        path = os.path.join(spec_dir, name)
+        if not name.endswith(".json") or os.path.abspath(path) == os.path.abspath(spec_path):
+            continue
+        with open(path) as f:
+            spec = json.load(f)
+        version = spec.get("info", {}).get("version", "")
+        if version:
+            specs.append((version, spec))
+    specs.sort(key=lambda s: version_key(s[0]))
+    return specs
+
+
+def set_schema_versions(history: list, api_version: str, resources: list):
+    """Set each resource's schema version by replaying the API versions in history.
+
+    Every API version that renames or retypes an attribute of a resource bumps its
+    schema version, so state written by an earlier package can be upgraded.
+    """
+    versions = [(version, find_resources(spec)) for version, spec in history]
+    versions.append((api_version, resources))
+
+    schema_versions = {}
+    changes = {}
+    previous_types = {}
+    for version, version_resources in versions:
+        for r in version_resources:
+            types = attribute_types(r)
+            old_types = previous_types.get(r.name)
+            if old_types is not None:
+                renamed = {
+                    to_tf_name(old): to_tf_name(new)
+                    for old, new in RENAMED_FIELDS.get(version, {}).get(r.name, {}).items()
+                    if to_tf_name(old) in old_types and to_tf_name(new) in types
+                }
+                old_types = {renamed.get(name, name): typ for name, typ in old_types.items()}
+                retyped = sorted(name for name, typ in types.items() if name in old_types and old_types[name] != typ)
+                if renamed or retyped:
+                    schema_versions[r.name] = schema_versions.get(r.name, 0) + 1
+                    changes.setdefault(r.name, []).append(
+                        SchemaChange(schema_versions[r.name], version, renamed, retyped)
+                    )
+            previous_types[r.name] = types
+
+    for r in resources:
+        r.schema_version = schema_versions.get(r.name, 0)
+        r.schema_changes = changes.get(r.name, [])
+
+
+def set_deprecations(resources: list, api_version: str, next_version: str = "", next_spec: dict = None):
+    """Set deprecation messages from deprecated: true in the spec and from the next spec.
+
+    Attributes and resources missing from the next API version are deprecated, so
+    configurations can be migrated before the orchestrator is upgraded.
+    """
+    deprecated = f"Deprecated in Blastshield API version {api_version}."
+    removed = f"Removed in Blastshield API version {next_version}. Migrate before upgrading the orchestrator."
+    next_resources = {r.name: r for r in find_resources(next_spec)} if next_spec else {}
+
+    for r in resources:
+        deprecate_flagged(r.fields, deprecated)
+        if r.deprecated:
+            r.deprecation_message = deprecated
+
+        if not next_spec:
+            continue
+        next_resource = next_resources.get(r.name)
+        if next_resource is None:
+            r.deprecation_message = removed
+            continue
+        renamed = {to_tf_name(old): to_tf_name(new) for old, new in RENAMED_FIELDS.get(next_version, {}).get(r.name, {}).items()}
+        next_attributes = attribute_types(next_resource)
+        next_fields = {f.tf_name: f for f in next_resource.fields}
+        for f in r.fields:
+            if f.tf_name in renamed:
+                f.deprecation_message = f"Renamed to {renamed[f.tf_name]} in Blastshield API version {next_version}."
+            elif f.tf_name not in next_attributes:
+                f.deprecation_message = removed
+            else:
+                deprecate_removed(f.nested_fields, next_fields[f.tf_name].nested_fields, removed)
+
+
+def deprecate_flagged(fields: list, message: str):
+    """Set message on the fields, nested ones included, flagged deprecated: true."""
+    for f in fields:
+        if f.deprecated:
+            f.deprecation_message = message
+        deprecate_flagged(f.nested_fields, message)
+
+
+def deprecate_removed(fields: list, next_fields: list, message: str):
+    """Set message on the nested fields missing from the next API version."""
+    next_by_name = {f.tf_name: f for f in next_fields}
+    for f in fields:
+        if f.tf_name not in next_by_name:
+            f.deprecation_message = message
+        else:
+            deprecate_removed(f.nested_fields, next_by_name[f.tf_name].nested_fields, message)
+
+
+def set_capabilities(spec: dict, resources: list):
+    """Set the attribute names and sub-endpoints registered as each resource's capabilities."""
+    for r in resources:
+        attributes = set(attribute_types(r))
+        if r.store_post_response:
+            attributes.add("invitation")
+        if r.has_groups:
+            attributes.add("groups")
+        if r.has_tags:
+            attributes.add("tags_all")
+        r.attributes = sorted(attributes)
+
+        entity_path = re.compile(re.escape(r.path) + r"\{[^/}]+\}(/.+)$")
+        r.sub_endpoints = sorted(m.group(1) for m in map(entity_path.match, spec.get("paths", {})) if m)
+
+
 def main():
     parser = argparse.ArgumentParser(description="Generate Terraform provider code from OpenAPI spec")
     parser.add_argument("--spec", default=DEFAULT_SPEC, help="Path to OpenAPI spec JSON file")
@@ -448,17 +621,34 @@ def main():
     package_name = args.package
 
     # Load OpenAPI spec
-    with open(spec_path) as f:
-        spec = json.load(f)
+    with open(spec_path, "rb") as f:
+        spec_bytes = f.read()
+    spec = json.loads(spec_bytes)
+    spec_hash = hashlib.sha256(spec_bytes).hexdigest()
 
     api_version = spec.get("info", {}).get("version", "unknown")
 
     # Find resources
     resources = find_resources(spec)
 
+    # Compare with the specs of earlier API versions to version the resource schemas,
+    # and with the next one to deprecate what it removes
+    siblings = load_sibling_specs(spec_path)
+    history = [(v, s) for v, s in siblings if version_key(v) < version_key(api_version)]
+    later = [(v, s) for v, s in siblings if version_key(v) > version_key(api_version)]
+    set_schema_versions(history, api_version, resources)
+    set_deprecations(resources, api_version, *(later[0] if later else ()))
+
     print(f"Found {len(resources)} resources (API version {api_version}, package {package_name}):")
     for r in resources:
         print(f"  - {r.name} ({r.path})")
+        for change in r.schema_changes:
+            print(f"      schema version {change.version} ({change.api_version}): renamed {change.renamed}, retyped {change.retyped}")
+        if r.deprecation_message:
+            print(f"      deprecated: {r.deprecation_message}")
+        for f in r.fields:
+            if f.deprecation_message:
+                print(f"      {f.tf_name} deprecated: {f.deprecation_message}")
 
     # Set up Jinja2 environment
     env = Environment(
@@ -482,18 +672,46 @@ def main():
                 if f.nested_ref not in nested_types:
                     nested_types[f.nested_ref] = f.nested_fields
 
-    # Check if resources have tags (for test generation)
+    # Single nested objects, and the objects of their nested lists, get helpers in
+    # types.go that convert them to and from Terraform. Read-only objects such as
+    # status are never sent, so only writable ones are converted back.
+    object_types = {}
+    writable_types = set()
+    for r in resources:
+        for f in r.fields:
+            if f.is_nested and not f.is_list and f.nested_fields:
+                refs = [f.nested_ref]
+                object_types.setdefault(f.nested_ref, f)
+                for nf in f.nested_fields:
+                    if nf.is_nested and nf.is_list and nf.nested_fields:
+                        refs.append(nf.nested_ref)
+                        object_types.setdefault(nf.nested_ref, nf)
+                if f.required or f.optional:
+                    writable_types.update(refs)
+    for type_name, f in object_types.items():
+        nested_types.setdefault(type_name, f.nested_fields)
+
+    # Check if resources have tags (for tags_all and test generation)
     for r in resources:
         r.has_tags = any(f.json_name == "tags" for f in r.create_fields)
 
+    # Register the attributes and sub-endpoints of each resource as capabilities
+    set_capabilities(spec, resources)
+
     # Generate shared files
     has_groups = any(r.has_groups for r in resources)
+    has_tags = any(r.has_tags for r in resources)
+    # Read-only objects such as status keep their state in plans
+    has_computed_objects = any(
+        f.is_nested and not f.is_list and f.nested_fields and f.computed and not f.optional
+        for r in resources for f in r.fields
+    )
     generated_files = [
-        ("schemas.go.j2", os.path.join(output_dir, "schemas.go"), {"resources": resources, "package_name": package_name}),
-        ("types.go.j2", os.path.join(output_dir, "types.go"), {"resources": resources, "nested_types": nested_types, "package_name": package_name}),
+        ("schemas.go.j2", os.path.join(output_dir, "schemas.go"), {"resources": resources, "has_computed_objects": has_computed_objects, "package_name": package_name}),
+        ("types.go.j2", os.path.join(output_dir, "types.go"), {"resources": resources, "nested_types": nested_types, "object_types": object_types, "writable_types": sorted(writable_types), "package_name": package_name}),
         ("client.go.j2", os.path.join(output_dir, "client.go"), {"package_name": package_name}),
-        ("helpers.go.j2", os.path.join(output_dir, "helpers.go"), {"has_groups": has_groups, "package_name": package_name}),
-        ("register.go.j2", os.path.join(output_dir, "register.go"), {"resources": resources, "api_version": api_version, "package_name": package_name}),
+        ("helpers.go.j2", os.path.join(output_dir, "helpers.go"), {"has_groups": has_groups, "has_tags": has_tags, "package_name": package_name}),
+        ("register.go.j2", os.path.join(output_dir, "register.go"), {"resources": resources, "api_version": api_version, "spec_hash": spec_hash, "package_name": package_name}),
         ("test_helpers.go.j2", os.path.join(output_dir, "test_helpers_test.go"), {"package_name": package_name}),
         ("resource_test.go.j2", os.path.join(output_dir, "resources_test.go"), {"resources": resources, "package_name": package_name}),
     ]
@@ -513,6 +731,11 @@ def main():
         nullable_required_fields = [f for f in r.create_fields if f.json_name in nullable_required]
         required_list_fields = [f for f in r.create_fields if f.required and f.is_list and not f.is_nested]
         nested_list_fields = [f for f in r.create_fields if f.is_nested and f.is_list and f.nested_fields]
+        create_field_names = {f.json_name for f in r.create_fields}
+        single_nested_fields = [
+            f for f in r.fields
+            if f.is_nested and not f.is_list and f.nested_fields and f.json_name in create_field_names
+        ]
 
         has_nested_list_fields = (
             any(f.is_nested and f.is_list and f.nested_fields for f in r.fields) or
@@ -532,6 +755,7 @@ def main():
             nullable_required_fields=nullable_required_fields,
             required_list_fields=required_list_fields,
             nested_list_fields=nested_list_fields,
+            single_nested_fields=single_nested_fields,
             has_nested_list_fields=has_nested_list_fields,
             id_value_method=id_value_method,
             id_format=id_format,
@@ -0,0 +1,227 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/apierror"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &APIInfoDataSource{}
+
+func NewAPIInfoDataSource() datasource.DataSource {
+	return &APIInfoDataSource{}
+}
+
+// APIInfoDataSource reports the orchestrator's API version, the API version the
+// provider uses for it, and the orchestrator's identity and license status. It is
+// not part of any generated version package, so it is available whatever the API
+// version.
+type APIInfoDataSource struct {
+	client *Client
+}
+
+type APIInfoModel struct {
+	ServerVersion     types.String `tfsdk:"server_version"`
+	APIVersion        types.String `tfsdk:"api_version"`
+	SupportedVersions types.List   `tfsdk:"supported_versions"`
+	Orchestrators     types.List   `tfsdk:"orchestrators"`
+	License           types.Object `tfsdk:"license"`
+}
+
+// orchestratorNode holds the fields of an orchestrator node the data source reports.
+type orchestratorNode struct {
+	ID        string  `json:"id"`
+	PublicKey *string `json:"public_key"`
+}
+
+type licenseStatus struct {
+	ValidLicense bool    `json:"valid_license"`
+	Offline      bool    `json:"offline"`
+	Expires      *string `json:"expires"`
+}
+
+func orchestratorAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"node_id":    types.StringType,
+		"public_key": types.StringType,
+	}
+}
+
+func licenseAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"valid":   types.BoolType,
+		"offline": types.BoolType,
+		"expires": types.StringType,
+	}
+}
+
+func (d *APIInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_info"
+}
+
+func (d *APIInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the Blastshield orchestrator's API version and the API version the provider uses for it, along with the orchestrator's identity and license status.",
+		Attributes: map[string]schema.Attribute{
+			"server_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The API version the orchestrator reports in its OpenAPI document. Null when the orchestrator does not serve its OpenAPI document.",
+			},
+			"api_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "The API version of the resource and data source implementations the provider uses for this orchestrator.",
+			},
+			"supported_versions": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The API versions this provider release supports, lowest first.",
+			},
+			"orchestrators": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The orchestrator nodes of the network.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The orchestrator's node ID.",
+						},
+						"public_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "The orchestrator's public key, if it has one.",
+						},
+					},
+				},
+			},
+			"license": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "The license status.",
+				Attributes: map[string]schema.Attribute{
+					"valid": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether the license is valid.",
+					},
+					"offline": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether the license is an offline license.",
+					},
+					"expires": schema.StringAttribute{
+						Computed:    true,
+						Description: "The date the license expires, if it does.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *APIInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	switch data := req.ProviderData.(type) {
+	case *boundClient:
+		d.client = data.Client
+	case *Client:
+		d.client = data
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+	}
+}
+
+func (d *APIInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, response *datasource.ReadResponse) {
+	if d.client == nil {
+		response.Diagnostics.AddError("Unconfigured Client", "blastshield_api_info was read before the provider was configured.")
+		return
+	}
+
+	var data APIInfoModel
+
+	// The versions recorded in Configure are reused. The OpenAPI document is
+	// fetched when the server version was not detected there, because api_version
+	// is set or the orchestrator was unreachable; failing to read it is only an
+	// error when the API version in use is unknown too.
+	serverVersion, apiVersion := d.client.serverVersion, d.client.apiVersion
+	if serverVersion == "" {
+		var err error
+		serverVersion, err = d.client.APIVersion(ctx)
+		switch {
+		case err != nil && apiVersion == "":
+			apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read API version", err)
+			return
+		case err != nil:
+			tflog.Warn(ctx, "Could not read server API version", map[string]interface{}{"error": err.Error()})
+		case apiVersion == "":
+			if _, ver, err := versions.SelectVersion(serverVersion); err == nil {
+				apiVersion = ver
+			}
+		}
+	}
+	data.ServerVersion = types.StringValue(serverVersion)
+	if serverVersion == "" {
+		data.ServerVersion = types.StringNull()
+	}
+	data.APIVersion = types.StringValue(apiVersion)
+
+	supported, diags := types.ListValueFrom(ctx, types.StringType, versions.Versions())
+	response.Diagnostics.Append(diags...)
+	data.SupportedVersions = supported
+
+	// The orchestrators are listed as nodes rather than read from the
+	// OrchestratorMeta of /license/request, as that endpoint also makes the
+	// orchestrator sign a new license request and CSR.
+	var nodes []orchestratorNode
+	if err := d.client.List(ctx, "/nodes/", map[string]string{"node_type": "O"}, &nodes); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read orchestrators", err)
+		return
+	}
+	orchestrators := make([]attr.Value, len(nodes))
+	for i, o := range nodes {
+		orchestrators[i] = types.ObjectValueMust(orchestratorAttrTypes(), map[string]attr.Value{
+			"node_id":    types.StringValue(o.ID),
+			"public_key": types.StringPointerValue(o.PublicKey),
+		})
+	}
+	orchestratorsVal, diags := types.ListValue(types.ObjectType{AttrTypes: orchestratorAttrTypes()}, orchestrators)
+	response.Diagnostics.Append(diags...)
+	data.Orchestrators = orchestratorsVal
+
+	var license licenseStatus
+	if err := d.client.Read(ctx, "/license/", &license); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read license status", err)
+		return
+	}
+	licenseVal, diags := types.ObjectValue(licenseAttrTypes(), map[string]attr.Value{
+		"valid":   types.BoolValue(license.ValidLicense),
+		"offline": types.BoolValue(license.Offline),
+		"expires": types.StringPointerValue(license.Expires),
+	})
+	response.Diagnostics.Append(diags...)
+	data.License = licenseVal
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readAPIInfo configures the data source with providerData and reads it.
+func readAPIInfo(t *testing.T, providerData interface{}) APIInfoModel {
+	t.Helper()
+	ctx := context.Background()
+
+	d := &APIInfoDataSource{}
+	var configureResp datasource.ConfigureResponse
+	d.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", configureResp.Diagnostics)
+	}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: null}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: null}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data APIInfoModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	return data
+}
+
+func newAPIInfoTestClient(t *testing.T) (*Client, map[string]*atomic.Int32) {
+	t.Helper()
+	return newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/openapi.json":
+			w.Write([]byte(`{"info": {"version": "99.1.0"}}`))
+		case "/nodes/":
+			if r.URL.Query().Get("node_type") != "O" {
+				t.Errorf("expected orchestrator nodes to be listed, got %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"id": "abc", "name": "orch", "node_type": "O", "public_key": "pk"}, {"id": "def", "name": "orch-2", "node_type": "O", "public_key": null}]`))
+		case "/license/":
+			w.Write([]byte(`{"valid_license": true, "offline": false, "expires": "2027-01-31"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestAPIInfoDataSource_Read(t *testing.T) {
+	vp, latest := versions.LatestVersion()
+	if vp == nil {
+		t.Skip("no API versions compiled in")
+	}
+	client, calls := newAPIInfoTestClient(t)
+	client.serverVersion, client.apiVersion = "99.1.0", latest
+
+	data := readAPIInfo(t, bindVersion(client, latest, vp))
+	if data.ServerVersion.ValueString() != "99.1.0" {
+		t.Errorf("expected server version 99.1.0, got %s", data.ServerVersion)
+	}
+	if data.APIVersion.ValueString() != latest {
+		t.Errorf("expected API version %s, got %s", latest, data.APIVersion)
+	}
+	if got := count(calls, "GET /openapi.json"); got != 0 {
+		t.Errorf("expected the configured versions to be reused, got %d requests", got)
+	}
+	if len(data.SupportedVersions.Elements()) != len(versions.Versions()) {
+		t.Errorf("expected the compiled versions, got %s", data.SupportedVersions)
+	}
+	orchestrators := data.Orchestrators.Elements()
+	if len(orchestrators) != 2 || orchestrators[0].(types.Object).Attributes()["node_id"].String() != `"abc"` {
+		t.Fatalf("expected two orchestrators, got %s", data.Orchestrators)
+	}
+	if !orchestrators[1].(types.Object).Attributes()["public_key"].IsNull() {
+		t.Errorf("expected a null public key, got %s", orchestrators[1])
+	}
+	license := data.License.Attributes()
+	if license["valid"].String() != "true" || license["expires"].String() != `"2027-01-31"` {
+		t.Errorf("unexpected license status: %s", data.License)
+	}
+}
+
+func TestAPIInfoDataSource_ReadVersions(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+
+	// A pinned or configured version is kept, and the server version is read
+	client, calls := newAPIInfoTestClient(t)
+	client.apiVersion = latest
+	data := readAPIInfo(t, client)
+	if data.ServerVersion.ValueString() != "99.1.0" || data.APIVersion.ValueString() != latest {
+		t.Errorf("expected API version %s and server version 99.1.0, got %s and %s", latest, data.APIVersion, data.ServerVersion)
+	}
+	if got := count(calls, "GET /openapi.json"); got != 1 {
+		t.Errorf("expected one version request, got %d", got)
+	}
+
+	// A server version that cannot be read is null when the API version is known
+	client, _ = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nodes/":
+			w.Write([]byte(`[]`))
+		case "/license/":
+			w.Write([]byte(`{"valid_license": true, "offline": false, "expires": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client.apiVersion = latest
+	data = readAPIInfo(t, client)
+	if !data.ServerVersion.IsNull() || data.APIVersion.ValueString() != latest {
+		t.Errorf("expected API version %s without a server version, got %s and %s", latest, data.APIVersion, data.ServerVersion)
+	}
+
+	// Without either version the server is asked
+	client, calls = newAPIInfoTestClient(t)
+	data = readAPIInfo(t, client)
+	if data.ServerVersion.ValueString() != "99.1.0" || data.APIVersion.ValueString() != latest {
+		t.Errorf("expected the detected versions, got %s and %s", data.ServerVersion, data.APIVersion)
+	}
+	if got := count(calls, "GET /openapi.json"); got != 1 {
+		t.Errorf("expected one version request, got %d", got)
+	}
+}
+
+func TestAPIInfoDataSource_ReadUnconfigured(t *testing.T) {
+	ctx := context.Background()
+	d := &APIInfoDataSource{}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: null}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: null}}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error reading without a configured client")
+	}
+}
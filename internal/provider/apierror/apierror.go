@@ -0,0 +1,127 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apierror defines the error type returned by the Blastshield API client.
+// It is shared by the provider package and the generated version packages.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for use with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// FieldError is a single entry of an HTTPValidationError response.
+type FieldError struct {
+	// Loc is the location of the invalid value, e.g. ["body", "protocols", 0, "ports"].
+	// Elements are strings for object keys and numbers for list indexes.
+	Loc  []interface{} `json:"loc"`
+	Msg  string        `json:"msg"`
+	Type string        `json:"type"`
+}
+
+// APIError is returned for any API response with a status code of 400 or above.
+type APIError struct {
+	StatusCode int
+	// Detail is the message from an HTTPError response, if the body was one, with
+	// secrets redacted.
+	Detail string
+	// Fields holds the entries of an HTTPValidationError response, if the body was
+	// one, with secrets redacted from their messages.
+	Fields []FieldError
+	// Body is the raw response body with secrets redacted.
+	Body []byte
+}
+
+// New decodes an error response body into an APIError.
+func New(statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Body:       RedactJSON(body),
+	}
+
+	var raw struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil || len(raw.Detail) == 0 {
+		return e
+	}
+
+	var detail string
+	if err := json.Unmarshal(raw.Detail, &detail); err == nil {
+		e.Detail = RedactText(detail)
+		return e
+	}
+
+	var fields []FieldError
+	if err := json.Unmarshal(raw.Detail, &fields); err == nil {
+		for i, f := range fields {
+			fields[i] = redactFieldError(f)
+		}
+		e.Fields = fields
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Detail != "":
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Detail)
+	case len(e.Fields) > 0:
+		msgs := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			msgs[i] = f.String()
+		}
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, strings.Join(msgs, "; "))
+	default:
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, string(e.Body))
+	}
+}
+
+// Is matches the sentinel errors by status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity || len(e.Fields) > 0
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
+
+// String formats the field error as "<dotted location>: <message>".
+func (f FieldError) String() string {
+	parts := make([]string, len(f.Loc))
+	for i, l := range f.Loc {
+		parts[i] = fmt.Sprint(l)
+	}
+	if len(parts) == 0 {
+		return f.Msg
+	}
+	return strings.Join(parts, ".") + ": " + f.Msg
+}
@@ -0,0 +1,151 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestNew_HTTPError(t *testing.T) {
+	err := New(404, []byte(`{"detail": "Node not found"}`))
+
+	if err.Detail != "Node not found" {
+		t.Errorf("expected detail to be decoded, got %q", err.Detail)
+	}
+	if err.Error() != "API error (status 404): Node not found" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected errors.Is(err, ErrNotFound)")
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Error("did not expect errors.Is(err, ErrConflict)")
+	}
+}
+
+func TestNew_HTTPValidationError(t *testing.T) {
+	body := `{"detail": [
+		{"loc": ["body", "name"], "msg": "Field required", "type": "missing"},
+		{"loc": ["body", "protocols", 0, "ports"], "msg": "Invalid port", "type": "value_error"}
+	]}`
+	err := New(422, []byte(body))
+
+	if len(err.Fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %d", len(err.Fields))
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("expected errors.Is(err, ErrValidation)")
+	}
+	expected := "API error (status 422): body.name: Field required; body.protocols.0.ports: Invalid port"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestNew_UnstructuredBody(t *testing.T) {
+	err := New(502, []byte("Bad Gateway"))
+
+	if err.Error() != "API error (status 502): Bad Gateway" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+}
+
+func TestIs_WrappedError(t *testing.T) {
+	err := fmt.Errorf("reading node: %w", New(401, []byte(`{"detail": "Invalid token"}`)))
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("expected errors.Is to see through wrapping")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Error("expected errors.As to find the APIError")
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	body := `{"detail": [{"loc": ["body", "smtp", "password"], "msg": "too short", "input": "hunter2"}],
+		"registration_token": "abc123", "public_key": "visible", "nested": {"openid_client_secret": "s3cret"}}`
+
+	redacted := string(RedactJSON([]byte(body)))
+
+	for _, secret := range []string{"hunter2", "abc123", "s3cret"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("expected %q to be redacted from %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "visible") {
+		t.Errorf("expected public_key to be kept in %s", redacted)
+	}
+}
+
+func TestNew_RedactsBody(t *testing.T) {
+	err := New(500, []byte(`{"token": "abc123"}`))
+
+	if strings.Contains(err.Error(), "abc123") {
+		t.Errorf("expected token to be redacted from %q", err.Error())
+	}
+}
+
+func TestNew_RedactsMessages(t *testing.T) {
+	err := New(400, []byte(`{"detail": "Cannot verify registration_token=abc123 (Authorization: Bearer s3cret)"}`))
+	if strings.Contains(err.Detail, "abc123") || strings.Contains(err.Detail, "s3cret") {
+		t.Errorf("expected secrets to be redacted from the detail %q", err.Detail)
+	}
+	if !strings.Contains(err.Detail, "registration_token="+RedactedValue) {
+		t.Errorf("expected the redacted name to be kept in %q", err.Detail)
+	}
+
+	body := `{"detail": [
+		{"loc": ["body", "smtp", "password"], "msg": "Value error, 'hunter2' is too short", "type": "value_error"},
+		{"loc": ["body", "name"], "msg": "Value error, 'edge-1' is taken", "type": "value_error"}
+	]}`
+	err = New(422, []byte(body))
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("expected the echoed password to be redacted from %q", err.Error())
+	}
+	if !strings.Contains(err.Fields[1].Msg, "'edge-1'") {
+		t.Errorf("expected values of other fields to be kept, got %q", err.Fields[1].Msg)
+	}
+}
+
+func TestAddError_ValidationAttributes(t *testing.T) {
+	body := `{"detail": [
+		{"loc": ["body", "name"], "msg": "Field required", "type": "missing"},
+		{"loc": ["body", "protocols", 0, "ports"], "msg": "Invalid port", "type": "value_error"},
+		{"loc": ["body"], "msg": "Invalid body", "type": "value_error"}
+	]}`
+	var diags diag.Diagnostics
+	AddError(&diags, "Client Error", "Unable to create service", New(422, []byte(body)))
+
+	if diags.ErrorsCount() != 3 {
+		t.Fatalf("expected 3 errors, got %d", diags.ErrorsCount())
+	}
+
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Errorf("expected first error on attribute name, got %v", diags[0])
+	}
+	withPath, ok = diags[1].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("protocols")) {
+		t.Errorf("expected second error on attribute protocols, got %v", diags[1])
+	}
+	if _, ok := diags[2].(diag.DiagnosticWithPath); ok {
+		t.Errorf("expected third error without attribute path, got %v", diags[2])
+	}
+}
+
+func TestAddError_OtherErrors(t *testing.T) {
+	var diags diag.Diagnostics
+	AddError(&diags, "Client Error", "Unable to read node", errors.New("connection refused"))
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %d", diags.ErrorsCount())
+	}
+	if diags[0].Detail() != "Unable to read node: connection refused" {
+		t.Errorf("unexpected detail: %s", diags[0].Detail())
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apierror
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// AddError appends err to diags as "<detail>: <error>". Validation failures that
+// name a request field are reported against the matching attribute, so Terraform
+// points at the offending configuration line.
+func AddError(diags *diag.Diagnostics, summary, detail string, err error) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		diags.AddError(summary, fmt.Sprintf("%s: %s", detail, err))
+		return
+	}
+
+	for _, f := range apiErr.Fields {
+		if p, ok := f.AttributePath(); ok {
+			diags.AddAttributeError(p, summary, fmt.Sprintf("%s: %s", detail, f.Msg))
+		} else {
+			diags.AddError(summary, fmt.Sprintf("%s: %s", detail, f))
+		}
+	}
+}
+
+// AttributePath maps the location of a validation error to a Terraform attribute
+// path. The leading "body", "query" or "path" element is dropped and list indexes
+// end the path, since list attributes are modelled as sets. It returns false when
+// the location does not name an attribute.
+func (f FieldError) AttributePath() (path.Path, bool) {
+	loc := f.Loc
+	if len(loc) > 0 {
+		switch loc[0] {
+		case "body", "query", "path":
+			loc = loc[1:]
+		}
+	}
+	if len(loc) == 0 {
+		return path.Empty(), false
+	}
+
+	name, ok := loc[0].(string)
+	if !ok {
+		return path.Empty(), false
+	}
+	p := path.Root(name)
+	for _, l := range loc[1:] {
+		child, ok := l.(string)
+		if !ok {
+			break
+		}
+		p = p.AtName(child)
+	}
+	return p, true
+}
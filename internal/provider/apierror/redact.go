@@ -0,0 +1,119 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apierror
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces the value of every sensitive key.
+const RedactedValue = "***REDACTED***"
+
+// sensitiveKeys are JSON keys whose values are never shown to the user.
+var sensitiveKeys = map[string]bool{
+	"authorization":        true,
+	"key":                  true,
+	"key_hash":             true,
+	"private_key":          true,
+	"registration_token":   true,
+	"secret":               true,
+	"token":                true,
+	"openid_client_secret": true,
+}
+
+// sensitiveAssignment matches a sensitive name followed by ":" or "=" and its value
+// in a free-form message, as in "token=abc123" or "Authorization: Bearer abc123".
+var sensitiveAssignment = regexp.MustCompile(`(?i)([\w-]*(?:authorization|key|password|secret|token)[\w-]*["']?\s*[:=]\s*)((?:(?:basic|bearer)\s+)?(?:"[^"]*"|'[^']*'|[^\s,;]+))`)
+
+// quotedValue matches a quoted value echoed in a message.
+var quotedValue = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+
+// IsSensitiveKey reports whether values stored under the JSON key k must be redacted.
+func IsSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	return sensitiveKeys[k] ||
+		strings.Contains(k, "password") ||
+		strings.Contains(k, "secret") ||
+		strings.HasSuffix(k, "_token")
+}
+
+// RedactJSON returns body with the values of sensitive keys replaced. Validation
+// errors echo the rejected input next to its location, so an "input" value is also
+// redacted when its "loc" names a sensitive key. Bodies that are not JSON are
+// returned unchanged.
+func RedactJSON(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// RedactText returns the free-form message s, such as an HTTPError detail, with the
+// values assigned to sensitive names replaced.
+func RedactText(s string) string {
+	return sensitiveAssignment.ReplaceAllString(s, "${1}"+RedactedValue)
+}
+
+// redactFieldError redacts the message of a validation error. Messages about a
+// sensitive field may echo the rejected value, so quoted values are dropped from them.
+func redactFieldError(f FieldError) FieldError {
+	f.Msg = RedactText(f.Msg)
+	if locIsSensitive(f.Loc) {
+		f.Msg = quotedValue.ReplaceAllString(f.Msg, RedactedValue)
+	}
+	return f
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if IsSensitiveKey(k) {
+				val[k] = RedactedValue
+				continue
+			}
+			val[k] = redactValue(child)
+		}
+		if loc, ok := val["loc"].([]interface{}); ok {
+			if _, hasInput := val["input"]; hasInput && locIsSensitive(loc) {
+				val["input"] = RedactedValue
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+func locIsSensitive(loc []interface{}) bool {
+	for _, l := range loc {
+		if s, ok := l.(string); ok && IsSensitiveKey(s) {
+			return true
+		}
+	}
+	return false
+}
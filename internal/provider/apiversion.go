@@ -0,0 +1,336 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiVersionCacheTTL is how long a detected API version is reused before the
+// OpenAPI document is fetched again.
+const apiVersionCacheTTL = time.Hour
+
+// APIVersion returns the orchestrator's API version from its OpenAPI document. The
+// document is fetched in a single short attempt, as callers fall back to a compiled
+// version rather than wait for retries.
+func (c *Client) APIVersion(ctx context.Context) (string, error) {
+	body, err := c.probe(ctx, "/openapi.json")
+	if err != nil {
+		return "", fmt.Errorf("fetching openapi.json: %w", err)
+	}
+	var spec struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(body, &spec); err != nil {
+		return "", fmt.Errorf("parsing openapi.json: %w", err)
+	}
+	if spec.Info.Version == "" {
+		return "", fmt.Errorf("openapi.json missing info.version")
+	}
+	return spec.Info.Version, nil
+}
+
+// versionCache remembers the API version detected for each host. Terraform starts
+// the provider several times per run, and without it every start downloads the
+// OpenAPI document. A nil *versionCache caches nothing.
+type versionCache struct {
+	path string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+type versionCacheEntry struct {
+	Version    string    `json:"version"`
+	DetectedAt time.Time `json:"detected_at"`
+	// SpecHash is the hex SHA-256 of the OpenAPI document stored for the dynamic
+	// version, if any. See putSpec.
+	SpecHash string `json:"spec_hash,omitempty"`
+}
+
+// defaultVersionCache returns the cache in TF_PLUGIN_CACHE_DIR, or in the user
+// cache directory when no plugin cache is configured.
+func defaultVersionCache() *versionCache {
+	file := ""
+	if dir := os.Getenv("TF_PLUGIN_CACHE_DIR"); dir != "" {
+		file = filepath.Join(dir, ".blastshield-api-versions.json")
+	} else if dir, err := os.UserCacheDir(); err == nil {
+		file = filepath.Join(dir, "terraform-provider-blastshield", "api-versions.json")
+	}
+	if file == "" {
+		return nil
+	}
+	return &versionCache{path: file, ttl: apiVersionCacheTTL, now: time.Now}
+}
+
+func (c *versionCache) load() map[string]versionCacheEntry {
+	entries := map[string]versionCacheEntry{}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return entries
+	}
+	// A corrupt cache is treated as empty and overwritten
+	_ = json.Unmarshal(data, &entries)
+	return entries
+}
+
+// get returns the version detected for host, if it has not expired.
+func (c *versionCache) get(host string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	entry, ok := c.load()[host]
+	if !ok || entry.Version == "" || c.now().Sub(entry.DetectedAt) > c.ttl {
+		return "", false
+	}
+	return entry.Version, true
+}
+
+// put records the version detected for host. A stored OpenAPI document is kept
+// as long as the version does not change.
+func (c *versionCache) put(host, version string) error {
+	if c == nil {
+		return nil
+	}
+	entries := c.load()
+	entry := versionCacheEntry{Version: version, DetectedAt: c.now()}
+	if prev := entries[host]; prev.Version == version {
+		entry.SpecHash = prev.SpecHash
+	}
+	entries[host] = entry
+	return c.save(entries)
+}
+
+// spec returns the OpenAPI document stored for host, if its entry has not expired.
+func (c *versionCache) spec(host string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.load()[host]
+	if !ok || entry.SpecHash == "" || c.now().Sub(entry.DetectedAt) > c.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.specPath(entry.SpecHash))
+	if err != nil {
+		return nil, false
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != entry.SpecHash {
+		return nil, false
+	}
+	return data, true
+}
+
+// putSpec records the version detected for host together with its OpenAPI
+// document, which is stored next to the cache file under its hash.
+func (c *versionCache) putSpec(host, version string, spec []byte) error {
+	if c == nil {
+		return nil
+	}
+	sum := sha256.Sum256(spec)
+	hash := hex.EncodeToString(sum[:])
+	if err := writeFileAtomic(c.specPath(hash), spec); err != nil {
+		return err
+	}
+	entries := c.load()
+	prev := entries[host].SpecHash
+	entries[host] = versionCacheEntry{Version: version, DetectedAt: c.now(), SpecHash: hash}
+	if err := c.save(entries); err != nil {
+		return err
+	}
+	// Drop the document this host replaced unless another host still uses it
+	for _, entry := range entries {
+		if entry.SpecHash == prev {
+			return nil
+		}
+	}
+	if prev != "" {
+		os.Remove(c.specPath(prev))
+	}
+	return nil
+}
+
+func (c *versionCache) specPath(hash string) string {
+	return filepath.Join(filepath.Dir(c.path), "openapi-"+hash+".json")
+}
+
+func (c *versionCache) save(entries map[string]versionCacheEntry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.path, data)
+}
+
+// writeFileAtomic replaces the file at path, as other provider processes may read
+// it at the same time.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".api-versions-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// versionOptions are the settings that steer API version selection.
+type versionOptions struct {
+	// override, from api_version or BLASTSHIELD_API_VERSION, replaces detection.
+	override string
+	// constraint, from api_version_constraint, must be met by the server version.
+	constraint string
+	cache      *versionCache
+}
+
+// selectAPIVersion picks the generated package for the orchestrator a provider
+// instance talks to. The server version is the configured override, the cached
+// version or the one read from the server, in that order. When the server version
+// cannot be read, the newest compiled version is used with a warning, as the server
+// may simply be unreachable during validation; a configured constraint still applies
+// to that fallback.
+//
+// It returns the selected package and its version, and the server version when it
+// was detected rather than configured.
+func selectAPIVersion(ctx context.Context, client *Client, opts versionOptions) (versions.VersionedProvider, string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var constraints version.Constraints
+	if opts.constraint != "" {
+		var err error
+		constraints, err = version.NewConstraint(opts.constraint)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("api_version_constraint"),
+				"Invalid API Version Constraint",
+				fmt.Sprintf("The API version constraint %q cannot be parsed: %s", opts.constraint, err),
+			)
+			return nil, "", "", diags
+		}
+	}
+
+	serverVersion := opts.override
+	switch {
+	case serverVersion != "":
+		tflog.Info(ctx, "Using configured API version", map[string]interface{}{"server_version": serverVersion})
+	default:
+		var cached bool
+		serverVersion, cached = opts.cache.get(client.Host)
+		if cached {
+			tflog.Debug(ctx, "Using cached API version", map[string]interface{}{
+				"host":           client.Host,
+				"server_version": serverVersion,
+			})
+			break
+		}
+
+		var err error
+		serverVersion, err = client.APIVersion(ctx)
+		if err != nil {
+			vp, ver := versions.LatestVersion()
+			if vp == nil {
+				diags.AddError("No API Versions Available", "The provider was built without any Blastshield API version packages.")
+				return nil, "", "", diags
+			}
+			tflog.Warn(ctx, "Could not fetch API version, using latest compiled version", map[string]interface{}{
+				"host":        client.Host,
+				"error":       err.Error(),
+				"api_version": ver,
+			})
+			if constraints != nil {
+				if lv, verr := version.NewVersion(ver); verr != nil || !constraints.Check(lv) {
+					diags.AddAttributeError(
+						path.Root("api_version_constraint"),
+						"API Version Constraint Not Met",
+						fmt.Sprintf("The API version of the Blastshield orchestrator at %s cannot be read (%s), and the API version %s the provider falls back to does not meet the constraint %q. Set api_version to the version the orchestrator runs.", client.Host, err, ver, opts.constraint),
+					)
+					return nil, "", "", diags
+				}
+			}
+			diags.AddWarning(
+				"API Version Not Detected",
+				fmt.Sprintf("The API version of the Blastshield orchestrator at %s cannot be read: %s. The provider uses API version %s, the newest it supports. Set api_version if the orchestrator runs an older version.", client.Host, err, ver),
+			)
+			return vp, ver, "", diags
+		}
+		if err := opts.cache.put(client.Host, serverVersion); err != nil {
+			tflog.Warn(ctx, "Could not cache API version", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	sv, err := version.NewVersion(serverVersion)
+	if err != nil {
+		diags.AddError(
+			"Invalid API Version",
+			fmt.Sprintf("The Blastshield API version %q of %s cannot be parsed: %s", serverVersion, client.Host, err),
+		)
+		return nil, "", "", diags
+	}
+	if constraints != nil && !constraints.Check(sv) {
+		diags.AddAttributeError(
+			path.Root("api_version_constraint"),
+			"API Version Constraint Not Met",
+			fmt.Sprintf("The Blastshield orchestrator at %s runs API version %s, which does not meet the constraint %q.", client.Host, serverVersion, opts.constraint),
+		)
+		return nil, "", "", diags
+	}
+
+	vp, ver, err := versions.SelectVersion(serverVersion)
+	if err != nil {
+		diags.AddError(
+			"Unsupported API Version",
+			fmt.Sprintf("The Blastshield orchestrator at %s runs API version %s, which this provider does not support: %s. Use a provider release that supports it.", client.Host, serverVersion, err),
+		)
+		return nil, "", "", diags
+	}
+	if pv, err := version.NewVersion(ver); err == nil && sv.GreaterThan(pv) {
+		diags.AddWarning(
+			"Newer API Version",
+			fmt.Sprintf("The Blastshield orchestrator at %s runs API version %s, which is newer than the API version %s this provider uses. Resources and attributes added since %s are not available until the provider is upgraded.", client.Host, serverVersion, ver, ver),
+		)
+	}
+	tflog.Info(ctx, "Selected API version", map[string]interface{}{
+		"host":           client.Host,
+		"server_version": serverVersion,
+		"api_version":    ver,
+		"spec_hash":      vp.Capabilities().SpecHash,
+	})
+	if opts.override != "" {
+		return vp, ver, "", diags
+	}
+	return vp, ver, serverVersion, diags
+}
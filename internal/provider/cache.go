@@ -0,0 +1,138 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// responseCache holds GET response bodies for the lifetime of the provider process.
+// Entries are keyed by path and query. Any write to a collection drops the cached
+// responses of that collection, and a write to a group membership also drops those
+// of the collection on the other side of it.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	// generations counts invalidations per collection, so a GET that was in flight
+	// during a write neither stores its result nor is joined by later readers.
+	generations map[string]uint64
+	flight      singleflight.Group
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries:     make(map[string][]byte),
+		generations: make(map[string]uint64),
+	}
+}
+
+// get returns the cached body for key, or calls fetch once for all concurrent
+// callers asking for the same key. The shared fetch is not cancelled when one of
+// the callers gives up; each caller still returns as soon as its own ctx is done.
+func (rc *responseCache) get(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	collections := collectionsOf(key)
+
+	rc.mu.Lock()
+	if body, ok := rc.entries[key]; ok {
+		rc.mu.Unlock()
+		return body, nil
+	}
+	gen := rc.generation(collections)
+	rc.mu.Unlock()
+
+	ch := rc.flight.DoChan(fmt.Sprintf("%d:%s", gen, key), func() (interface{}, error) {
+		body, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		rc.mu.Lock()
+		if rc.generation(collections) == gen {
+			rc.entries[key] = body
+		}
+		rc.mu.Unlock()
+		return body, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// invalidate drops every cached response of the collections that path belongs to.
+func (rc *responseCache) invalidate(path string) {
+	collections := collectionsOf(path)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for _, c := range collections {
+		rc.generations[c]++
+	}
+	for key := range rc.entries {
+		if slices.ContainsFunc(collectionsOf(key), func(c string) bool { return slices.Contains(collections, c) }) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// generation sums the invalidation counts of collections. As counts only grow, the
+// sum changes whenever any of the collections is invalidated. rc.mu must be held.
+func (rc *responseCache) generation(collections []string) uint64 {
+	var gen uint64
+	for _, c := range collections {
+		gen += rc.generations[c]
+	}
+	return gen
+}
+
+// memberCollections maps the path segments that name group memberships to the
+// collections whose responses show the other side of the membership.
+var memberCollections = map[string][]string{
+	"groups":    {"groups"},
+	"users":     {"nodes"},
+	"endpoints": {"endpoints"},
+	"members":   {"nodes", "endpoints"},
+}
+
+// collectionsOf returns the collections the responses of an API path belong to: its
+// first segment, e.g. "nodes" for "/nodes/?name=gw", followed for membership paths
+// by the other side of the membership, e.g. "groups" for "/nodes/12/groups".
+func collectionsOf(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	collections := []string{segments[0]}
+	for _, segment := range segments[1:] {
+		for _, c := range memberCollections[segment] {
+			if !slices.Contains(collections, c) {
+				collections = append(collections, c)
+			}
+		}
+	}
+	return collections
+}
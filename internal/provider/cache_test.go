@@ -0,0 +1,206 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCachingTestClient(t *testing.T, handler http.HandlerFunc) (*Client, map[string]*atomic.Int32) {
+	t.Helper()
+	var mu sync.Mutex
+	calls := map[string]*atomic.Int32{}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		key := r.Method + " " + r.URL.RequestURI()
+		if calls[key] == nil {
+			calls[key] = &atomic.Int32{}
+		}
+		calls[key].Add(1)
+		mu.Unlock()
+		handler(w, r)
+	})
+	client.EnableCache()
+	return client, calls
+}
+
+func count(calls map[string]*atomic.Int32, key string) int32 {
+	if c := calls[key]; c != nil {
+		return c.Load()
+	}
+	return 0
+}
+
+func TestCache_ServesRepeatedReads(t *testing.T) {
+	client, calls := newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	ctx := context.Background()
+
+	var result []interface{}
+	for i := 0; i < 3; i++ {
+		if err := client.ListWithMultiParams(ctx, "/nodes/", url.Values{"name": {"gw"}}, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := client.ListWithMultiParams(ctx, "/nodes/", url.Values{"name": {"other"}}, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := count(calls, "GET /nodes/?name=gw"); got != 1 {
+		t.Errorf("expected 1 request for the repeated query, got %d", got)
+	}
+	if got := count(calls, "GET /nodes/?name=other"); got != 1 {
+		t.Errorf("expected a different query to be fetched separately, got %d", got)
+	}
+}
+
+func TestCache_CoalescesConcurrentReads(t *testing.T) {
+	client, calls := newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`[]`))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result []interface{}
+			if err := client.Read(context.Background(), "/groups/", &result); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := count(calls, "GET /groups/"); got != 1 {
+		t.Errorf("expected concurrent reads to share 1 request, got %d", got)
+	}
+}
+
+func TestCache_WritesInvalidateCollection(t *testing.T) {
+	client, calls := newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	ctx := context.Background()
+
+	var result map[string]interface{}
+	read := func(path string) {
+		t.Helper()
+		if err := client.Read(ctx, path, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	read("/nodes/1")
+	read("/services/1")
+	if err := client.Update(ctx, "/nodes/1/groups", map[string]string{"op": "replace"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("/nodes/1")
+	read("/services/1")
+
+	if got := count(calls, "GET /nodes/1"); got != 2 {
+		t.Errorf("expected the write to invalidate /nodes/1, got %d requests", got)
+	}
+	if got := count(calls, "GET /services/1"); got != 1 {
+		t.Errorf("expected other collections to stay cached, got %d requests", got)
+	}
+}
+
+func TestCache_DoesNotCacheErrors(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	client, calls := newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	ctx := context.Background()
+
+	var result map[string]interface{}
+	if err := client.Read(ctx, "/nodes/1", &result); err == nil {
+		t.Fatal("expected an error")
+	}
+	fail.Store(false)
+	if err := client.Read(ctx, "/nodes/1", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := count(calls, "GET /nodes/1"); got != 2 {
+		t.Errorf("expected the failed read to be retried, got %d requests", got)
+	}
+}
+
+func TestCache_MembershipWritesInvalidateGroups(t *testing.T) {
+	client, calls := newCachingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	ctx := context.Background()
+
+	var result map[string]interface{}
+	read := func(path string) {
+		t.Helper()
+		if err := client.Read(ctx, path, &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	read("/groups/3")
+	read("/nodes/1/groups")
+	if err := client.Update(ctx, "/nodes/1/groups", map[string]string{"op": "replace"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("/groups/3")
+	if got := count(calls, "GET /groups/3"); got != 2 {
+		t.Errorf("expected a node membership write to invalidate groups, got %d requests", got)
+	}
+
+	read("/nodes/1/groups")
+	if err := client.Update(ctx, "/groups/3", map[string]string{"name": "ops"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("/nodes/1/groups")
+	if got := count(calls, "GET /nodes/1/groups"); got != 3 {
+		t.Errorf("expected a group write to invalidate node memberships, got %d requests", got)
+	}
+}
+
+func TestCollectionsOf(t *testing.T) {
+	cases := map[string][]string{
+		"/nodes/":               {"nodes"},
+		"/nodes/12/groups":      {"nodes", "groups"},
+		"/nodes/12/tags":        {"nodes"},
+		"/nodes/?name=gw":       {"nodes"},
+		"/groups/3/users":       {"groups", "nodes"},
+		"/groups/batch/members": {"groups", "nodes", "endpoints"},
+		"/settings/":            {"settings"},
+		"/openapi.json":         {"openapi.json"},
+	}
+	for path, want := range cases {
+		if got := collectionsOf(path); !slices.Equal(got, want) {
+			t.Errorf("collectionsOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
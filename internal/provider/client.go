@@ -16,21 +16,78 @@ package provider
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"math/rand/v2"
+	"net"
 	"net/http"
 	"net/url"
-	"os"
+	"reflect"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/apierror"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when max_retries is not configured.
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxWait is the longest delay between attempts when retry_max_wait is not configured.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryBaseWait = 1 * time.Second
 )
 
+// ErrReadOnly is returned for any request other than GET when the client is read-only.
+var ErrReadOnly = errors.New("the provider is read-only")
+
 type Client struct {
-	Host       string
-	Token      string
-	HTTPClient *http.Client
+	Host  string
+	Token string
+	// TokenSource, if set, supplies the token for each request instead of Token.
+	TokenSource TokenSource
+	HTTPClient  *http.Client
+
+	// MaxRetries is how many times a failed request is retried before giving up.
+	MaxRetries int
+	// RetryMaxWait caps the delay between attempts, including delays requested via Retry-After.
+	RetryMaxWait time.Duration
+
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Headers are extra headers sent with every request. They cannot replace the
+	// headers set by the client itself.
+	Headers map[string]string
+
+	// Tags holds the default_tags and ignore_tags settings applied by resources.
+	Tags *tagging.Config
+	// ReadOnly refuses every request other than GET.
+	ReadOnly bool
+
+	// sem bounds the number of requests in flight and limiter the request rate.
+	// Both are nil when unlimited. See SetLimits.
+	sem     chan struct{}
+	limiter *rate.Limiter
+
+	// cache holds GET responses when enabled with EnableCache.
+	cache *responseCache
+
+	// serverVersion is the API version the orchestrator reported and apiVersion the
+	// version whose implementations serve it. Configure records them when known, so
+	// the api_info data source does not fetch the OpenAPI document again.
+	serverVersion string
+	apiVersion    string
 }
 
 func NewClient(host, token string) *Client {
@@ -40,11 +97,193 @@ func NewClient(host, token string) *Client {
 		HTTPClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		MaxRetries:   DefaultMaxRetries,
+		RetryMaxWait: DefaultRetryMaxWait,
 	}
 }
 
-func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+// SetLimits bounds the number of concurrent requests and the sustained request
+// rate. Zero disables the corresponding limit. Every resource and data source of a
+// provider instance shares the same Client, so the limits apply to all of them.
+func (c *Client) SetLimits(maxConcurrent int, requestsPerSecond float64) {
+	c.sem = nil
+	if maxConcurrent > 0 {
+		c.sem = make(chan struct{}, maxConcurrent)
+	}
+	c.limiter = nil
+	if requestsPerSecond > 0 {
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(requestsPerSecond)))
+	}
+}
+
+// token returns the token to authenticate the next request with.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.TokenSource != nil {
+		return c.TokenSource.Token(ctx)
+	}
+	return c.Token, nil
+}
+
+// EnableCache turns on caching of GET responses for the lifetime of the client.
+// Concurrent identical GETs are sent once, and any POST, PUT or DELETE drops the
+// cached responses of the collection it writes to.
+func (c *Client) EnableCache() {
+	c.cache = newResponseCache()
+}
+
+// TagConfig returns the provider's tag settings. It is nil when none are configured.
+func (c *Client) TagConfig() *tagging.Config {
+	return c.Tags
+}
+
+// IsReadOnly reports whether the provider is configured with read_only. Resources
+// use it to reject changes at plan time.
+func (c *Client) IsReadOnly() bool {
+	return c.ReadOnly
+}
+
+// acquire waits for the rate limiter and a concurrency slot. The returned
+// function releases the slot.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.sem <- struct{}{}:
+		return func() { <-c.sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// logSubsystem is the tflog subsystem for API traffic. Its entries carry the
+// module "blastshield.http" and its level can be set separately with
+// TF_LOG_PROVIDER_BLASTSHIELD_HTTP.
+const logSubsystem = "http"
+
+// logContext adds the HTTP logging subsystem to ctx. Secrets that may appear in
+// log fields, such as the API token and custom header values, are masked.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem,
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER_BLASTSHIELD", logSubsystem),
+		tflog.WithRootFields(),
+	)
+	return tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, c.secrets()...)
+}
+
+// secrets returns the credentials that must never appear in log output.
+func (c *Client) secrets() []string {
+	var secrets []string
+	if c.Token != "" {
+		secrets = append(secrets, c.Token)
+	}
+	for _, v := range c.Headers {
+		if v != "" {
+			secrets = append(secrets, v)
+		}
+	}
+	return secrets
+}
+
+// logBody is a request or response body in a log field. Redaction is deferred until
+// the entry is written, so large list responses cost nothing when trace logging is
+// off. tflog only masks string field values, so the secrets are masked here as well.
+type logBody struct {
+	body    []byte
+	secrets []string
+}
+
+func (b logBody) String() string {
+	s := string(apierror.RedactJSON(b.body))
+	for _, secret := range b.secrets {
+		s = strings.ReplaceAll(s, secret, apierror.RedactedValue)
+	}
+	return s
+}
+
+func (b logBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// logHeaders returns the request headers for logging, with credentials and custom
+// header values redacted. Custom headers are matched whatever the case of their
+// configured names, since h holds canonical keys.
+func (c *Client) logHeaders(h http.Header) map[string]string {
+	custom := make(map[string]bool, len(c.Headers))
+	for name := range c.Headers {
+		custom[http.CanonicalHeaderKey(name)] = true
+	}
+	out := make(map[string]string, len(h))
+	for k := range h {
+		switch {
+		case custom[k], apierror.IsSensitiveKey(k), strings.EqualFold(k, "Proxy-Authorization"), strings.EqualFold(k, "Cookie"):
+			out[k] = apierror.RedactedValue
+		default:
+			out[k] = h.Get(k)
+		}
+	}
+	return out
+}
+
+// apiResponse holds the parts of an HTTP response needed after the body is closed.
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// token is the API token the request was sent with.
+	token string
+}
+
+// doRequest performs a request, serving GETs from the response cache when it is enabled.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	if c.cache == nil {
+		return c.roundTrip(ctx, method, path, body, nil)
+	}
+	if method == http.MethodGet {
+		return c.cache.get(ctx, path, func(ctx context.Context) ([]byte, error) {
+			return c.roundTrip(ctx, method, path, nil, nil)
+		})
+	}
+	// A failed write may still have changed something, so invalidate regardless.
+	defer c.cache.invalidate(path)
+	return c.roundTrip(ctx, method, path, body, nil)
+}
+
+// probeTimeout bounds a probe, so an unreachable orchestrator does not hold up a
+// caller that has a fallback.
+const probeTimeout = 10 * time.Second
+
+// probe GETs path once, without retries or the response cache, giving up after
+// probeTimeout. It serves requests whose callers fall back to something else when
+// the orchestrator cannot be reached.
+func (c *Client) probe(ctx context.Context, path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(c.logContext(ctx), probeTimeout)
+	defer cancel()
+
+	resp, err := c.send(ctx, http.MethodGet, c.Host+path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, apierror.New(resp.StatusCode, resp.Body)
+	}
+	return resp.Body, nil
+}
+
+// roundTrip performs a request, retrying it according to the retry settings. When
+// decode is set, a successful response body is passed to it as a stream instead of
+// being returned.
+func (c *Client) roundTrip(ctx context.Context, method, path string, body interface{}, decode func(io.Reader) error) ([]byte, error) {
+	// Resources already fail at plan time; this catches anything that slips through
+	if c.ReadOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("refusing %s %s: %w", method, path, ErrReadOnly)
+	}
+
 	var jsonBody []byte
 	if body != nil {
 		var err error
@@ -52,54 +291,387 @@ func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
 	fullURL := c.Host + path
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	ctx = c.logContext(ctx)
+	refreshedToken := false
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, fullURL, jsonBody, decode)
+
+		// A rejected token may have been revoked or rotated before its advertised
+		// expiry. Fetch a new one and try again, once, without using up a retry.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.TokenSource != nil && !refreshedToken {
+			refreshedToken = true
+			c.TokenSource.Invalidate(resp.token)
+			tflog.SubsystemDebug(ctx, logSubsystem, "Blastshield API rejected the token, fetching a new one", map[string]interface{}{
+				"method": method,
+				"path":   path,
+			})
+			attempt--
+			continue
+		}
+
+		if attempt < c.MaxRetries && ctx.Err() == nil && shouldRetry(method, resp, err) {
+			wait := c.retryWait(attempt, resp)
+			fields := map[string]interface{}{
+				"method":      method,
+				"path":        path,
+				"attempt":     attempt + 1,
+				"max_retries": c.MaxRetries,
+				"wait":        wait.String(),
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			} else {
+				fields["status"] = resp.StatusCode
+			}
+			tflog.SubsystemWarn(ctx, logSubsystem, "Retrying Blastshield API request", fields)
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, fmt.Errorf("request cancelled while waiting to retry: %w", err)
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			return nil, apierror.New(resp.StatusCode, resp.Body)
+		}
+		return resp.Body, nil
+	}
+}
+
+// send performs a single HTTP round trip. A nil response is returned only together with an error.
+// If decode is set, it consumes the body of a successful response and Body is left empty.
+func (c *Client) send(ctx context.Context, method, fullURL string, jsonBody []byte, decode func(io.Reader) error) (*apiResponse, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, &tokenError{err: err}
+	}
+	ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, token)
+	secrets := append(c.secrets(), token)
+
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	// Setting Accept-Encoding ourselves turns off the transport's transparent
+	// decompression, so the body is gunzipped below.
+	req.Header.Set("Accept-Encoding", "gzip")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
-	// Log request if TF_LOG is set
-	if os.Getenv("TF_LOG") != "" {
-		log.Printf("[DEBUG] Blastshield API Request: %s %s", method, fullURL)
-		if len(jsonBody) > 0 {
-			log.Printf("[DEBUG] Blastshield API Request Body: %s", string(jsonBody))
-		}
+	fields := map[string]interface{}{
+		"method": method,
+		"path":   req.URL.Path,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = req.URL.RawQuery
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending Blastshield API request", fields, map[string]interface{}{
+		"headers": c.logHeaders(req.Header),
+	})
+	if len(jsonBody) > 0 {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Blastshield API request body", fields, map[string]interface{}{
+			"body": logBody{body: jsonBody, secrets: secrets},
+		})
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("request cancelled while waiting for rate limit: %w", err)
 	}
+	defer release()
 
+	start := time.Now()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Blastshield API request failed", fields, map[string]interface{}{
+			"duration_ms": time.Since(start).Milliseconds(),
+			"error":       err.Error(),
+		})
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	body, err := responseBody(resp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Log response if TF_LOG is set
-	if os.Getenv("TF_LOG") != "" {
-		log.Printf("[DEBUG] Blastshield API Response: %d %s", resp.StatusCode, string(respBody))
+	fields["status"] = resp.StatusCode
+	if decode != nil && resp.StatusCode < 400 {
+		err := decode(body)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Received Blastshield API response", fields, map[string]interface{}{
+			"duration_ms": time.Since(start).Milliseconds(),
+			"streamed":    true,
+		})
+		if err != nil {
+			return nil, &decodeError{err: err}
+		}
+		return &apiResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			token:      token,
+		}, nil
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+	respBody, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return respBody, nil
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received Blastshield API response", fields, map[string]interface{}{
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	tflog.SubsystemTrace(ctx, logSubsystem, "Blastshield API response body", fields, map[string]interface{}{
+		"body": logBody{body: respBody, secrets: secrets},
+	})
+
+	return &apiResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+		token:      token,
+	}, nil
+}
+
+// responseBody returns the body of resp, decompressing it if the server used gzip.
+func responseBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+	gz, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		// Empty body, e.g. 204 No Content
+		return http.NoBody, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return gz, nil
+}
+
+// decodeError is returned when a streamed response body could not be decoded.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("failed to decode response body: %v", e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// tokenError is returned when no token could be obtained for a request.
+type tokenError struct {
+	err error
+}
+
+func (e *tokenError) Error() string {
+	return fmt.Sprintf("failed to get API token: %v", e.err)
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.err
+}
+
+// decodeList decodes a JSON array into result, which must point to a slice, one
+// element at a time. Unlike json.Unmarshal it never holds the whole body in memory,
+// which matters for collections with tens of thousands of entries. Any other result
+// type is decoded as a single value.
+//
+// Decoding element by element costs about a fifth more CPU time than unmarshalling
+// a buffered body, with the same number of allocations; streaming is still the
+// default because peak memory, not decoding time, limits large lists. See
+// BenchmarkList_Buffered and BenchmarkList_Streaming.
+func decodeList(r io.Reader, result interface{}) error {
+	dec := json.NewDecoder(r)
+
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Slice {
+		return dec.Decode(result)
+	}
+	slice := rv.Elem()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		slice.SetZero()
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array, got %v", tok)
+	}
+
+	// Decode each element in place at the end of the slice to avoid a copy per
+	// element. Growing the slice through reflect.Append would allocate for every
+	// element, so its length is extended directly instead.
+	out := reflect.New(slice.Type()).Elem()
+	out.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	for n := 0; dec.More(); n++ {
+		if n == out.Cap() {
+			out.Grow(1)
+		}
+		out.SetLen(n + 1)
+		if err := dec.Decode(out.Index(n).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	slice.Set(out)
+	return nil
+}
+
+// shouldRetry reports whether a request may be attempted again. GET, PUT and DELETE
+// are idempotent and are retried on connection errors, timeouts, 429 and 5xx
+// responses. Any other method (in practice POST) is only retried when the connection
+// itself failed, because the server may already have acted on a request that
+// produced a response or timed out. Other errors, such as rejected certificates or a
+// failing token command, fail the same way on every attempt and are not retried.
+func shouldRetry(method string, resp *apiResponse, err error) bool {
+	idempotent := method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+
+	if err != nil {
+		var tokErr *tokenError
+		if errors.As(err, &tokErr) || isTLSError(err) {
+			return false
+		}
+		// A body that does not match the expected type will not match on the next
+		// attempt either; only retry if the connection broke while streaming it.
+		var decErr *decodeError
+		if errors.As(err, &decErr) {
+			return idempotent && isConnectionError(decErr.err)
+		}
+		if idempotent && isTimeout(err) {
+			return true
+		}
+		return isConnectionError(err)
+	}
+
+	if !idempotent {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// isConnectionError reports whether err means the connection was refused, reset or
+// dropped before a response was received.
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isTimeout reports whether err means the request or the connection timed out.
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTLSError reports whether err means the TLS handshake failed, for example
+// because the server certificate is not trusted or does not match the host.
+func isTLSError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &recordErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After header
+// takes precedence; otherwise exponential backoff with jitter is used. The result
+// never exceeds RetryMaxWait.
+func (c *Client) retryWait(attempt int, resp *apiResponse) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, c.RetryMaxWait)
+		}
+	}
+
+	backoff := c.RetryMaxWait
+	if attempt < 30 {
+		backoff = min(retryBaseWait<<attempt, c.RetryMaxWait)
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Equal jitter: wait at least half the backoff so retries keep spreading out.
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// sleepContext waits for d to elapse or for ctx to be done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
 }
 
 // Generic CRUD operations
 
-func (c *Client) Create(path string, body interface{}, result interface{}) error {
-	respBody, err := c.doRequest(http.MethodPost, path, body)
+func (c *Client) Create(ctx context.Context, path string, body interface{}, result interface{}) error {
+	respBody, err := c.doRequest(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return err
 	}
@@ -110,12 +682,12 @@ func (c *Client) Create(path string, body interface{}, result interface{}) error
 }
 
 // CreateRaw returns the raw JSON response from a POST request
-func (c *Client) CreateRaw(path string, body interface{}) ([]byte, error) {
-	return c.doRequest(http.MethodPost, path, body)
+func (c *Client) CreateRaw(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	return c.doRequest(ctx, http.MethodPost, path, body)
 }
 
-func (c *Client) Read(path string, result interface{}) error {
-	respBody, err := c.doRequest(http.MethodGet, path, nil)
+func (c *Client) Read(ctx context.Context, path string, result interface{}) error {
+	respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return err
 	}
@@ -125,8 +697,8 @@ func (c *Client) Read(path string, result interface{}) error {
 	return nil
 }
 
-func (c *Client) Update(path string, body interface{}, result interface{}) error {
-	respBody, err := c.doRequest(http.MethodPut, path, body)
+func (c *Client) Update(ctx context.Context, path string, body interface{}, result interface{}) error {
+	respBody, err := c.doRequest(ctx, http.MethodPut, path, body)
 	if err != nil {
 		return err
 	}
@@ -136,64 +708,63 @@ func (c *Client) Update(path string, body interface{}, result interface{}) error
 	return nil
 }
 
-func (c *Client) Delete(path string) error {
-	_, err := c.doRequest(http.MethodDelete, path, nil)
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, path, nil)
 	return err
 }
 
-func (c *Client) DeleteWithBody(path string, body interface{}) error {
-	_, err := c.doRequest(http.MethodDelete, path, body)
+func (c *Client) DeleteWithBody(ctx context.Context, path string, body interface{}) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, path, body)
 	return err
 }
 
-func (c *Client) List(path string, params map[string]string, result interface{}) error {
-	if len(params) > 0 {
-		values := url.Values{}
-		for k, v := range params {
-			values.Add(k, v)
-		}
-		path = path + "?" + values.Encode()
+func (c *Client) List(ctx context.Context, path string, params map[string]string, result interface{}) error {
+	values := url.Values{}
+	for k, v := range params {
+		values.Add(k, v)
 	}
-	respBody, err := c.doRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return err
-	}
-	if result != nil {
-		return json.Unmarshal(respBody, result)
-	}
-	return nil
+	return c.ListWithMultiParams(ctx, path, values, result)
 }
 
-func (c *Client) ListWithMultiParams(path string, params url.Values, result interface{}) error {
+// ListWithMultiParams fetches a collection into result, a pointer to a slice. The
+// response is decoded as it arrives rather than buffered, unless the response cache
+// is enabled and needs the raw body.
+func (c *Client) ListWithMultiParams(ctx context.Context, path string, params url.Values, result interface{}) error {
 	if len(params) > 0 {
 		path = path + "?" + params.Encode()
 	}
-	respBody, err := c.doRequest(http.MethodGet, path, nil)
-	if err != nil {
-		return err
-	}
-	if result != nil {
-		return json.Unmarshal(respBody, result)
+	if c.cache != nil || result == nil {
+		respBody, err := c.doRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return err
+		}
+		if result != nil {
+			return decodeList(bytes.NewReader(respBody), result)
+		}
+		return nil
 	}
-	return nil
+	_, err := c.roundTrip(ctx, http.MethodGet, path, nil, func(r io.Reader) error {
+		return decodeList(r, result)
+	})
+	return err
 }
 
 // GetGroupsRaw gets group memberships for a resource - for generated code compatibility
 // The result parameter should be a pointer to a slice (e.g., *[]GroupMembership)
-func (c *Client) GetGroupsRaw(basePath string, id interface{}, result interface{}) error {
+func (c *Client) GetGroupsRaw(ctx context.Context, basePath string, id interface{}, result interface{}) error {
 	path := fmt.Sprintf("%s%v/groups", basePath, id)
-	return c.Read(path, result)
+	return c.Read(ctx, path, result)
 }
 
 // UpdateGroupsRaw updates group memberships for a resource - for generated code compatibility
 // groups should be a slice of group memberships, result should be a pointer to receive results
-func (c *Client) UpdateGroupsRaw(basePath string, id interface{}, groups interface{}, result interface{}) error {
+func (c *Client) UpdateGroupsRaw(ctx context.Context, basePath string, id interface{}, groups interface{}, result interface{}) error {
 	path := fmt.Sprintf("%s%v/groups", basePath, id)
 	req := map[string]interface{}{
 		"op":     "replace",
 		"groups": groups,
 	}
-	return c.Update(path, req, result)
+	return c.Update(ctx, path, req, result)
 }
 
 // Node operations
@@ -247,29 +818,29 @@ type InvitationResponse struct {
 	Offline           bool    `json:"offline"`
 }
 
-func (c *Client) CreateNode(req *NodeCreateRequest) (*InvitationResponse, error) {
+func (c *Client) CreateNode(ctx context.Context, req *NodeCreateRequest) (*InvitationResponse, error) {
 	var result InvitationResponse
-	err := c.Create("/nodes/", req, &result)
+	err := c.Create(ctx, "/nodes/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetNode(id string) (*NodeResponse, error) {
+func (c *Client) GetNode(ctx context.Context, id string) (*NodeResponse, error) {
 	if id == "" {
 		return nil, fmt.Errorf("node ID is empty")
 	}
 	var result NodeResponse
-	err := c.Read("/nodes/"+id, &result)
+	err := c.Read(ctx, "/nodes/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateNode(id string, req interface{}) (*NodeResponse, error) {
+func (c *Client) UpdateNode(ctx context.Context, id string, req interface{}) (*NodeResponse, error) {
 	var result NodeResponse
-	err := c.Update("/nodes/"+id, req, &result)
+	err := c.Update(ctx, "/nodes/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteNode(id string) error {
-	return c.Delete("/nodes/" + id)
+func (c *Client) DeleteNode(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/nodes/"+id)
 }
 
 // GroupWithExpiry represents a group membership with optional expiry
@@ -284,16 +855,16 @@ type GroupList struct {
 	Groups []GroupWithExpiry `json:"groups"`
 }
 
-func (c *Client) GetNodeGroups(id string) ([]GroupWithExpiry, error) {
+func (c *Client) GetNodeGroups(ctx context.Context, id string) ([]GroupWithExpiry, error) {
 	if id == "" {
 		return nil, fmt.Errorf("node ID is empty")
 	}
 	var result []GroupWithExpiry
-	err := c.Read("/nodes/"+id+"/groups", &result)
+	err := c.Read(ctx, "/nodes/"+id+"/groups", &result)
 	return result, err
 }
 
-func (c *Client) UpdateNodeGroups(id string, groups []GroupWithExpiry) ([]GroupWithExpiry, error) {
+func (c *Client) UpdateNodeGroups(ctx context.Context, id string, groups []GroupWithExpiry) ([]GroupWithExpiry, error) {
 	if id == "" {
 		return nil, fmt.Errorf("node ID is empty")
 	}
@@ -302,13 +873,13 @@ func (c *Client) UpdateNodeGroups(id string, groups []GroupWithExpiry) ([]GroupW
 		Groups: groups,
 	}
 	var result []GroupWithExpiry
-	err := c.Update("/nodes/"+id+"/groups", req, &result)
+	err := c.Update(ctx, "/nodes/"+id+"/groups", req, &result)
 	return result, err
 }
 
-func (c *Client) ListNodes(params map[string]string) ([]NodeResponse, error) {
+func (c *Client) ListNodes(ctx context.Context, params map[string]string) ([]NodeResponse, error) {
 	var result []NodeResponse
-	err := c.List("/nodes/", params, &result)
+	err := c.List(ctx, "/nodes/", params, &result)
 	return result, err
 }
 
@@ -340,38 +911,38 @@ type EndpointResponse struct {
 	Status    map[string]interface{} `json:"status"`
 }
 
-func (c *Client) CreateEndpoint(req *EndpointCreateRequest) (*EndpointResponse, error) {
+func (c *Client) CreateEndpoint(ctx context.Context, req *EndpointCreateRequest) (*EndpointResponse, error) {
 	var result EndpointResponse
-	err := c.Create("/endpoints/", req, &result)
+	err := c.Create(ctx, "/endpoints/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetEndpoint(id string) (*EndpointResponse, error) {
+func (c *Client) GetEndpoint(ctx context.Context, id string) (*EndpointResponse, error) {
 	var result EndpointResponse
-	err := c.Read("/endpoints/"+id, &result)
+	err := c.Read(ctx, "/endpoints/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateEndpoint(id string, req interface{}) (*EndpointResponse, error) {
+func (c *Client) UpdateEndpoint(ctx context.Context, id string, req interface{}) (*EndpointResponse, error) {
 	var result EndpointResponse
-	err := c.Update("/endpoints/"+id, req, &result)
+	err := c.Update(ctx, "/endpoints/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteEndpoint(id string) error {
-	return c.Delete("/endpoints/" + id)
+func (c *Client) DeleteEndpoint(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/endpoints/"+id)
 }
 
-func (c *Client) GetEndpointGroups(id string) ([]GroupWithExpiry, error) {
+func (c *Client) GetEndpointGroups(ctx context.Context, id string) ([]GroupWithExpiry, error) {
 	if id == "" {
 		return nil, fmt.Errorf("endpoint ID is empty")
 	}
 	var result []GroupWithExpiry
-	err := c.Read("/endpoints/"+id+"/groups", &result)
+	err := c.Read(ctx, "/endpoints/"+id+"/groups", &result)
 	return result, err
 }
 
-func (c *Client) UpdateEndpointGroups(id string, groups []GroupWithExpiry) ([]GroupWithExpiry, error) {
+func (c *Client) UpdateEndpointGroups(ctx context.Context, id string, groups []GroupWithExpiry) ([]GroupWithExpiry, error) {
 	if id == "" {
 		return nil, fmt.Errorf("endpoint ID is empty")
 	}
@@ -380,13 +951,13 @@ func (c *Client) UpdateEndpointGroups(id string, groups []GroupWithExpiry) ([]Gr
 		Groups: groups,
 	}
 	var result []GroupWithExpiry
-	err := c.Update("/endpoints/"+id+"/groups", req, &result)
+	err := c.Update(ctx, "/endpoints/"+id+"/groups", req, &result)
 	return result, err
 }
 
-func (c *Client) ListEndpoints(params map[string]string) ([]EndpointResponse, error) {
+func (c *Client) ListEndpoints(ctx context.Context, params map[string]string) ([]EndpointResponse, error) {
 	var result []EndpointResponse
-	err := c.List("/endpoints/", params, &result)
+	err := c.List(ctx, "/endpoints/", params, &result)
 	return result, err
 }
 
@@ -421,37 +992,37 @@ type GroupResponse struct {
 	Users          []GroupMemberStr  `json:"users"`
 }
 
-func (c *Client) CreateGroup(req *GroupCreateRequest) (*GroupResponse, error) {
+func (c *Client) CreateGroup(ctx context.Context, req *GroupCreateRequest) (*GroupResponse, error) {
 	var result GroupResponse
-	err := c.Create("/groups/", req, &result)
+	err := c.Create(ctx, "/groups/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetGroup(id string) (*GroupResponse, error) {
+func (c *Client) GetGroup(ctx context.Context, id string) (*GroupResponse, error) {
 	var result GroupResponse
-	err := c.Read("/groups/"+id, &result)
+	err := c.Read(ctx, "/groups/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateGroup(id string, req interface{}) (*GroupResponse, error) {
+func (c *Client) UpdateGroup(ctx context.Context, id string, req interface{}) (*GroupResponse, error) {
 	var result GroupResponse
-	err := c.Update("/groups/"+id, req, &result)
+	err := c.Update(ctx, "/groups/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteGroup(id string) error {
-	return c.Delete("/groups/" + id)
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/groups/"+id)
 }
 
-func (c *Client) ListGroups(params map[string]string) ([]GroupResponse, error) {
+func (c *Client) ListGroups(ctx context.Context, params map[string]string) ([]GroupResponse, error) {
 	var result []GroupResponse
-	err := c.List("/groups/", params, &result)
+	err := c.List(ctx, "/groups/", params, &result)
 	return result, err
 }
 
-func (c *Client) ListGroupsWithParams(params url.Values) ([]GroupResponse, error) {
+func (c *Client) ListGroupsWithParams(ctx context.Context, params url.Values) ([]GroupResponse, error) {
 	var result []GroupResponse
-	err := c.ListWithMultiParams("/groups/", params, &result)
+	err := c.ListWithMultiParams(ctx, "/groups/", params, &result)
 	return result, err
 }
 
@@ -477,31 +1048,31 @@ type ServiceResponse struct {
 	Protocols []ProtocolSpec    `json:"protocols"`
 }
 
-func (c *Client) CreateService(req *ServiceCreateRequest) (*ServiceResponse, error) {
+func (c *Client) CreateService(ctx context.Context, req *ServiceCreateRequest) (*ServiceResponse, error) {
 	var result ServiceResponse
-	err := c.Create("/services/", req, &result)
+	err := c.Create(ctx, "/services/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetService(id string) (*ServiceResponse, error) {
+func (c *Client) GetService(ctx context.Context, id string) (*ServiceResponse, error) {
 	var result ServiceResponse
-	err := c.Read("/services/"+id, &result)
+	err := c.Read(ctx, "/services/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateService(id string, req interface{}) (*ServiceResponse, error) {
+func (c *Client) UpdateService(ctx context.Context, id string, req interface{}) (*ServiceResponse, error) {
 	var result ServiceResponse
-	err := c.Update("/services/"+id, req, &result)
+	err := c.Update(ctx, "/services/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteService(id string) error {
-	return c.Delete("/services/" + id)
+func (c *Client) DeleteService(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/services/"+id)
 }
 
-func (c *Client) ListServices(params map[string]string) ([]ServiceResponse, error) {
+func (c *Client) ListServices(ctx context.Context, params map[string]string) ([]ServiceResponse, error) {
 	var result []ServiceResponse
-	err := c.List("/services/", params, &result)
+	err := c.List(ctx, "/services/", params, &result)
 	return result, err
 }
 
@@ -528,31 +1099,31 @@ type PolicyResponse struct {
 	Services   []int64           `json:"services"`
 }
 
-func (c *Client) CreatePolicy(req *PolicyCreateRequest) (*PolicyResponse, error) {
+func (c *Client) CreatePolicy(ctx context.Context, req *PolicyCreateRequest) (*PolicyResponse, error) {
 	var result PolicyResponse
-	err := c.Create("/policies/", req, &result)
+	err := c.Create(ctx, "/policies/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetPolicy(id string) (*PolicyResponse, error) {
+func (c *Client) GetPolicy(ctx context.Context, id string) (*PolicyResponse, error) {
 	var result PolicyResponse
-	err := c.Read("/policies/"+id, &result)
+	err := c.Read(ctx, "/policies/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdatePolicy(id string, req interface{}) (*PolicyResponse, error) {
+func (c *Client) UpdatePolicy(ctx context.Context, id string, req interface{}) (*PolicyResponse, error) {
 	var result PolicyResponse
-	err := c.Update("/policies/"+id, req, &result)
+	err := c.Update(ctx, "/policies/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeletePolicy(id string) error {
-	return c.Delete("/policies/" + id)
+func (c *Client) DeletePolicy(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/policies/"+id)
 }
 
-func (c *Client) ListPolicies(params map[string]string) ([]PolicyResponse, error) {
+func (c *Client) ListPolicies(ctx context.Context, params map[string]string) ([]PolicyResponse, error) {
 	var result []PolicyResponse
-	err := c.List("/policies/", params, &result)
+	err := c.List(ctx, "/policies/", params, &result)
 	return result, err
 }
 
@@ -586,31 +1157,31 @@ type EgressPolicyResponse struct {
 	DNSNames           []DNSNameSpec     `json:"dns_names"`
 }
 
-func (c *Client) CreateEgressPolicy(req *EgressPolicyCreateRequest) (*EgressPolicyResponse, error) {
+func (c *Client) CreateEgressPolicy(ctx context.Context, req *EgressPolicyCreateRequest) (*EgressPolicyResponse, error) {
 	var result EgressPolicyResponse
-	err := c.Create("/egress_policies/", req, &result)
+	err := c.Create(ctx, "/egress_policies/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetEgressPolicy(id string) (*EgressPolicyResponse, error) {
+func (c *Client) GetEgressPolicy(ctx context.Context, id string) (*EgressPolicyResponse, error) {
 	var result EgressPolicyResponse
-	err := c.Read("/egress_policies/"+id, &result)
+	err := c.Read(ctx, "/egress_policies/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateEgressPolicy(id string, req interface{}) (*EgressPolicyResponse, error) {
+func (c *Client) UpdateEgressPolicy(ctx context.Context, id string, req interface{}) (*EgressPolicyResponse, error) {
 	var result EgressPolicyResponse
-	err := c.Update("/egress_policies/"+id, req, &result)
+	err := c.Update(ctx, "/egress_policies/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteEgressPolicy(id string) error {
-	return c.Delete("/egress_policies/" + id)
+func (c *Client) DeleteEgressPolicy(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/egress_policies/"+id)
 }
 
-func (c *Client) ListEgressPolicies(params map[string]string) ([]EgressPolicyResponse, error) {
+func (c *Client) ListEgressPolicies(ctx context.Context, params map[string]string) ([]EgressPolicyResponse, error) {
 	var result []EgressPolicyResponse
-	err := c.List("/egress_policies/", params, &result)
+	err := c.List(ctx, "/egress_policies/", params, &result)
 	return result, err
 }
 
@@ -641,31 +1212,31 @@ type ProxyResponse struct {
 	ExitAgents []string          `json:"exit_agents"`
 }
 
-func (c *Client) CreateProxy(req *ProxyCreateRequest) (*ProxyResponse, error) {
+func (c *Client) CreateProxy(ctx context.Context, req *ProxyCreateRequest) (*ProxyResponse, error) {
 	var result ProxyResponse
-	err := c.Create("/proxies/", req, &result)
+	err := c.Create(ctx, "/proxies/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetProxy(id string) (*ProxyResponse, error) {
+func (c *Client) GetProxy(ctx context.Context, id string) (*ProxyResponse, error) {
 	var result ProxyResponse
-	err := c.Read("/proxies/"+id, &result)
+	err := c.Read(ctx, "/proxies/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateProxy(id string, req interface{}) (*ProxyResponse, error) {
+func (c *Client) UpdateProxy(ctx context.Context, id string, req interface{}) (*ProxyResponse, error) {
 	var result ProxyResponse
-	err := c.Update("/proxies/"+id, req, &result)
+	err := c.Update(ctx, "/proxies/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteProxy(id string) error {
-	return c.Delete("/proxies/" + id)
+func (c *Client) DeleteProxy(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/proxies/"+id)
 }
 
-func (c *Client) ListProxies(params map[string]string) ([]ProxyResponse, error) {
+func (c *Client) ListProxies(ctx context.Context, params map[string]string) ([]ProxyResponse, error) {
 	var result []ProxyResponse
-	err := c.List("/proxies/", params, &result)
+	err := c.List(ctx, "/proxies/", params, &result)
 	return result, err
 }
 
@@ -692,31 +1263,31 @@ type EventLogRuleResponse struct {
 	ApplyToGroups   []int64           `json:"apply_to_groups"`
 }
 
-func (c *Client) CreateEventLogRule(req *EventLogRuleCreateRequest) (*EventLogRuleResponse, error) {
+func (c *Client) CreateEventLogRule(ctx context.Context, req *EventLogRuleCreateRequest) (*EventLogRuleResponse, error) {
 	var result EventLogRuleResponse
-	err := c.Create("/event_log_rules/", req, &result)
+	err := c.Create(ctx, "/event_log_rules/", req, &result)
 	return &result, err
 }
 
-func (c *Client) GetEventLogRule(id string) (*EventLogRuleResponse, error) {
+func (c *Client) GetEventLogRule(ctx context.Context, id string) (*EventLogRuleResponse, error) {
 	var result EventLogRuleResponse
-	err := c.Read("/event_log_rules/"+id, &result)
+	err := c.Read(ctx, "/event_log_rules/"+id, &result)
 	return &result, err
 }
 
-func (c *Client) UpdateEventLogRule(id string, req interface{}) (*EventLogRuleResponse, error) {
+func (c *Client) UpdateEventLogRule(ctx context.Context, id string, req interface{}) (*EventLogRuleResponse, error) {
 	var result EventLogRuleResponse
-	err := c.Update("/event_log_rules/"+id, req, &result)
+	err := c.Update(ctx, "/event_log_rules/"+id, req, &result)
 	return &result, err
 }
 
-func (c *Client) DeleteEventLogRule(id string) error {
-	return c.Delete("/event_log_rules/" + id)
+func (c *Client) DeleteEventLogRule(ctx context.Context, id string) error {
+	return c.Delete(ctx, "/event_log_rules/"+id)
 }
 
-func (c *Client) ListEventLogRules(params map[string]string) ([]EventLogRuleResponse, error) {
+func (c *Client) ListEventLogRules(ctx context.Context, params map[string]string) ([]EventLogRuleResponse, error) {
 	var result []EventLogRuleResponse
-	err := c.List("/event_log_rules/", params, &result)
+	err := c.List(ctx, "/event_log_rules/", params, &result)
 	return result, err
 }
 
@@ -734,8 +1305,8 @@ type SettingsResponse struct {
 	RemoteDesktop   map[string]interface{} `json:"remote_desktop"`
 }
 
-func (c *Client) GetSettings() (*SettingsResponse, error) {
+func (c *Client) GetSettings(ctx context.Context) (*SettingsResponse, error) {
 	var result SettingsResponse
-	err := c.Read("/settings/", &result)
+	err := c.Read(ctx, "/settings/", &result)
 	return &result, err
 }
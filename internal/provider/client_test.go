@@ -0,0 +1,570 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "test-token")
+	client.RetryMaxWait = 10 * time.Millisecond
+	return client, server
+}
+
+func TestDoRequest_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	var result map[string]interface{}
+	if err := client.Read(context.Background(), "/groups/1", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.MaxRetries = 2
+
+	if err := client.Delete(context.Background(), "/groups/1"); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_DoesNotRetryPostOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := client.CreateRaw(context.Background(), "/groups/", map[string]string{"name": "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_RetriesPostOnConnectionError(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Drop the connection without writing a response.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	if _, err := client.CreateRaw(context.Background(), "/groups/", map[string]string{"name": "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_DoesNotRetryTLSErrors(t *testing.T) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request over an untrusted connection")
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	// The client does not trust the test server's certificate
+	client := NewClient(server.URL, "test-token")
+	client.RetryMaxWait = 10 * time.Millisecond
+
+	if err := client.Read(context.Background(), "/groups/1", nil); err == nil {
+		t.Fatal("expected a certificate error")
+	}
+	if conns.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", conns.Load())
+	}
+}
+
+func TestDoRequest_DoesNotRetryTokenErrors(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request without a token")
+	})
+	client.TokenSource = failingTokenSource{calls: &calls}
+
+	if err := client.Read(context.Background(), "/groups/1", nil); err == nil {
+		t.Fatal("expected a token error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+type failingTokenSource struct {
+	calls *atomic.Int32
+}
+
+func (s failingTokenSource) Token(ctx context.Context) (string, error) {
+	s.calls.Add(1)
+	return "", errors.New("token command exited with status 1")
+}
+
+func (s failingTokenSource) Invalidate(token string) {}
+
+func TestShouldRetry_TransportErrors(t *testing.T) {
+	dialErr := &url.Error{Op: "Get", URL: "https://orchestrator", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	timeoutErr := &url.Error{Op: "Get", URL: "https://orchestrator", Err: os.ErrDeadlineExceeded}
+
+	tests := map[string]struct {
+		method string
+		err    error
+		want   bool
+	}{
+		"dial":              {http.MethodGet, dialErr, true},
+		"dial on post":      {http.MethodPost, dialErr, true},
+		"timeout":           {http.MethodGet, timeoutErr, true},
+		"timeout on post":   {http.MethodPost, timeoutErr, false},
+		"unknown authority": {http.MethodGet, &url.Error{Op: "Get", URL: "https://orchestrator", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, false},
+		"token":             {http.MethodGet, &tokenError{err: errors.New("expired")}, false},
+		"other":             {http.MethodGet, errors.New("unsupported protocol scheme"), false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := shouldRetry(tc.method, nil, tc.err); got != tc.want {
+				t.Errorf("shouldRetry(%s, %v) = %v, want %v", tc.method, tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDoRequest_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	if err := client.Read(context.Background(), "/groups/1", nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_ReadOnlyRefusesWrites(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"id": 1}`))
+	})
+	client.ReadOnly = true
+	ctx := context.Background()
+
+	if _, err := client.CreateRaw(ctx, "/groups/", map[string]string{"name": "test"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from POST, got %v", err)
+	}
+	if err := client.Update(ctx, "/groups/1", map[string]string{"name": "test"}, nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from PUT, got %v", err)
+	}
+	if err := client.Delete(ctx, "/groups/1"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from DELETE, got %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("expected no writes to reach the server, got %d requests", calls.Load())
+	}
+
+	var result map[string]interface{}
+	if err := client.Read(ctx, "/groups/1", &result); err != nil {
+		t.Fatalf("unexpected error from GET: %v", err)
+	}
+}
+
+func TestDoRequest_StopsRetryingWhenContextCancelled(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.RetryMaxWait = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.Read(ctx, "/groups/1", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("request was not cancelled promptly, took %s", elapsed)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestDoRequest_CancelsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	err := client.Read(ctx, "/groups/1", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestRetryWait_HonorsRetryAfter(t *testing.T) {
+	client := NewClient("http://localhost", "test-token")
+	client.RetryMaxWait = 10 * time.Second
+
+	resp := &apiResponse{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "4")
+	if wait := client.retryWait(0, resp); wait != 4*time.Second {
+		t.Errorf("expected 4s, got %s", wait)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if wait := client.retryWait(0, resp); wait != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped at 10s, got %s", wait)
+	}
+}
+
+func TestRetryWait_ExponentialBackoff(t *testing.T) {
+	client := NewClient("http://localhost", "test-token")
+	client.RetryMaxWait = 5 * time.Second
+
+	for attempt, expected := range []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		wait := client.retryWait(attempt, nil)
+		if wait < expected/2 || wait > expected {
+			t.Errorf("attempt %d: expected wait in [%s, %s], got %s", attempt, expected/2, expected, wait)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("expected empty header to be ignored")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected invalid header to be ignored")
+	}
+	if wait, ok := parseRetryAfter("7"); !ok || wait != 7*time.Second {
+		t.Errorf("expected 7s, got %s (ok=%t)", wait, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 {
+		t.Errorf("expected positive wait for HTTP date, got %s (ok=%t)", wait, ok)
+	}
+}
+
+func TestClient_SendsUserAgentAndCustomHeaders(t *testing.T) {
+	var got http.Header
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	})
+	client.UserAgent = UserAgent("1.2.3", "1.9.0")
+	client.Headers = map[string]string{
+		"X-Proxy-Auth":  "secret",
+		"Authorization": "Basic override",
+	}
+
+	var result map[string]interface{}
+	if err := client.Read(context.Background(), "/nodes/1", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ua := got.Get("User-Agent"); ua != "terraform-provider-blastshield/1.2.3 terraform/1.9.0" {
+		t.Errorf("unexpected User-Agent %q", ua)
+	}
+	if h := got.Get("X-Proxy-Auth"); h != "secret" {
+		t.Errorf("expected custom header to be sent, got %q", h)
+	}
+	if auth := got.Get("Authorization"); auth != "Bearer test-token" {
+		t.Errorf("expected custom headers not to replace Authorization, got %q", auth)
+	}
+}
+
+func TestClient_LimitsConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+	client.SetLimits(2, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var result map[string]interface{}
+			if err := client.Read(context.Background(), "/nodes/1", &result); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestClient_RateLimitsRequests(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	client.SetLimits(0, 20)
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		var result map[string]interface{}
+		if err := client.Read(context.Background(), "/nodes/1", &result); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// A burst of 20 is allowed, the remaining 5 requests take at least 250ms.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, took %s", elapsed)
+	}
+}
+
+func TestClient_RateLimitHonorsContext(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	client.SetLimits(0, 0.1)
+
+	var result map[string]interface{}
+	if err := client.Read(context.Background(), "/nodes/1", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.Read(ctx, "/nodes/1", &result); err == nil {
+		t.Fatal("expected the rate limited request to fail when the context ends")
+	}
+}
+
+func TestClient_LogsRedactSecrets(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "registration_token": "reg-secret"}`))
+	})
+	client.Headers = map[string]string{"X-Proxy-Auth": "proxy-secret", "x-tenant-key": "tenant-secret"}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	var result map[string]interface{}
+	body := map[string]interface{}{"name": "gw", "password": "hunter2"}
+	if err := client.Create(ctx, "/nodes/", body, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, secret := range []string{"test-token", "proxy-secret", "tenant-secret", "reg-secret", "hunter2"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("expected %q to be redacted from logs:\n%s", secret, output.String())
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding log output: %v", err)
+	}
+	var found bool
+	for _, e := range entries {
+		if e["@message"] != "Received Blastshield API response" {
+			continue
+		}
+		found = true
+		if e["@module"] != "provider.http" || e["method"] != "POST" || e["path"] != "/nodes/" || e["status"] != float64(200) {
+			t.Errorf("unexpected response log entry: %v", e)
+		}
+		if _, ok := e["duration_ms"]; !ok {
+			t.Errorf("expected duration_ms in %v", e)
+		}
+	}
+	if !found {
+		t.Errorf("expected a response log entry in:\n%s", output.String())
+	}
+}
+
+func TestClient_DecompressesGzipResponses(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected gzip to be requested, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		if r.URL.Path == "/nodes/" {
+			gz.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		} else {
+			gz.Write([]byte(`{"id": 1}`))
+		}
+		gz.Close()
+	})
+
+	var list []map[string]interface{}
+	if err := client.ListWithMultiParams(context.Background(), "/nodes/", nil, &list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("expected 2 items, got %d", len(list))
+	}
+
+	var single map[string]interface{}
+	if err := client.Read(context.Background(), "/nodes/1", &single); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestListWithMultiParams_RetriesStreamedList(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"id": 1}]`))
+	})
+
+	var list []struct {
+		ID int `json:"id"`
+	}
+	if err := client.ListWithMultiParams(context.Background(), "/nodes/", nil, &list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != 1 {
+		t.Errorf("unexpected result %+v", list)
+	}
+}
+
+func TestListWithMultiParams_DoesNotRetryDecodeErrors(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`[{"id": "not a number"}]`))
+	})
+
+	var list []struct {
+		ID int `json:"id"`
+	}
+	if err := client.ListWithMultiParams(context.Background(), "/nodes/", nil, &list); err == nil {
+		t.Fatal("expected a decode error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestDecodeList(t *testing.T) {
+	var list []int
+	if err := decodeList(strings.NewReader(`[1, 2, 3]`), &list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 3 || list[2] != 3 {
+		t.Errorf("unexpected result %v", list)
+	}
+
+	if err := decodeList(strings.NewReader(`[]`), &list); err != nil || list == nil || len(list) != 0 {
+		t.Errorf("expected an empty, non-nil slice, got %#v, %v", list, err)
+	}
+
+	if err := decodeList(strings.NewReader(`null`), &list); err != nil || list != nil {
+		t.Errorf("expected null to reset the slice, got %v, %v", list, err)
+	}
+
+	if err := decodeList(strings.NewReader(`{"detail": "x"}`), &list); err == nil {
+		t.Error("expected an error for an object")
+	}
+
+	if err := decodeList(strings.NewReader(`[1, 2`), &list); err == nil {
+		t.Error("expected an error for a truncated array")
+	}
+
+	var obj map[string]string
+	if err := decodeList(strings.NewReader(`{"detail": "x"}`), &obj); err != nil || obj["detail"] != "x" {
+		t.Errorf("expected non-slice results to be decoded as a value, got %v, %v", obj, err)
+	}
+}
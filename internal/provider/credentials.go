@@ -0,0 +1,253 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is used when no profile is selected and the credentials file has one.
+const DefaultProfile = "default"
+
+// Connection holds everything needed to reach an orchestrator.
+type Connection struct {
+	Host  string
+	Token string
+	// TokenFile is a file holding the token. At most one of Token and TokenFile is set.
+	TokenFile string
+	Transport TransportConfig
+}
+
+// Profile is a named entry of the credentials file.
+type Profile struct {
+	Host               string `json:"host"`
+	Token              string `json:"token"`
+	TokenFile          string `json:"token_file"`
+	CACertFile         string `json:"ca_cert_file"`
+	CACertPEM          string `json:"ca_cert_pem"`
+	ClientCert         string `json:"client_cert"`
+	ClientKey          string `json:"client_key"`
+	TLSServerName      string `json:"tls_server_name"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+}
+
+// CredentialsFile returns the path of the credentials file: BLASTSHIELD_CREDENTIALS_FILE
+// if set, otherwise ~/.config/blastshield/credentials.
+func CredentialsFile() string {
+	if file := os.Getenv("BLASTSHIELD_CREDENTIALS_FILE"); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "blastshield", "credentials")
+}
+
+// ConnectionFromEnv resolves the connection settings from the environment and the
+// credentials file. The profile is the given name, or BLASTSHIELD_PROFILE when name
+// is empty; if neither is set the "default" profile is used when the file has one.
+// Environment variables take precedence over a profile selected through
+// BLASTSHIELD_PROFILE or by default, but a profile named explicitly wins over them
+// and the environment only fills the settings it leaves unset.
+// Configure applies the provider block on top of the result.
+func ConnectionFromEnv(name string) (Connection, error) {
+	explicit := name != ""
+	if !explicit {
+		name = os.Getenv("BLASTSHIELD_PROFILE")
+	}
+
+	var conn Connection
+	profile, err := LoadProfile(CredentialsFile(), name)
+	if err != nil {
+		return conn, err
+	}
+	if profile != nil {
+		conn = profile.Connection()
+	}
+
+	env := connectionFromEnvVars()
+	if explicit {
+		if conn.Host == "" {
+			conn.Host = env.Host
+		}
+		if conn.Token == "" && conn.TokenFile == "" {
+			conn.Token, conn.TokenFile = env.Token, env.TokenFile
+		}
+		conn.Transport = env.Transport.Merge(conn.Transport)
+		return conn, nil
+	}
+
+	if env.Host != "" {
+		conn.Host = env.Host
+	}
+	if env.Token != "" || env.TokenFile != "" {
+		conn.Token, conn.TokenFile = env.Token, env.TokenFile
+	}
+	conn.Transport = conn.Transport.Merge(env.Transport)
+	return conn, nil
+}
+
+// connectionFromEnvVars reads the BLASTSHIELD_* connection variables.
+// BLASTSHIELD_TOKEN takes precedence over BLASTSHIELD_TOKEN_FILE.
+func connectionFromEnvVars() Connection {
+	conn := Connection{
+		Host:      os.Getenv("BLASTSHIELD_HOST"),
+		Token:     os.Getenv("BLASTSHIELD_TOKEN"),
+		Transport: TransportConfigFromEnv(),
+	}
+	if conn.Token == "" {
+		conn.TokenFile = os.Getenv("BLASTSHIELD_TOKEN_FILE")
+	}
+	return conn
+}
+
+// LoadProfile reads the named profile from the credentials file at path. An empty
+// name selects the "default" profile, and returns nil without error when the file
+// or the default profile does not exist. A profile requested by name must exist.
+func LoadProfile(path, name string) (*Profile, error) {
+	explicit := name != ""
+	if !explicit {
+		name = DefaultProfile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading credentials file: %w", err)
+	}
+
+	profiles, err := parseCredentials(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing credentials file %s: %w", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %q not found in credentials file %s", name, path)
+	}
+	return &profile, nil
+}
+
+// Connection returns the connection settings held by the profile.
+func (p Profile) Connection() Connection {
+	token, tokenFile := p.Token, p.TokenFile
+	if token != "" {
+		tokenFile = ""
+	}
+	return Connection{
+		Host:      p.Host,
+		Token:     token,
+		TokenFile: tokenFile,
+		Transport: TransportConfig{
+			CACertFile:         p.CACertFile,
+			CACertPEM:          p.CACertPEM,
+			ClientCert:         p.ClientCert,
+			ClientKey:          p.ClientKey,
+			TLSServerName:      p.TLSServerName,
+			InsecureSkipVerify: p.InsecureSkipVerify,
+		},
+	}
+}
+
+// parseCredentials parses a credentials file in JSON format, an object of profiles
+// keyed by name, or in INI format with one section per profile.
+func parseCredentials(data []byte) (map[string]Profile, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var profiles map[string]Profile
+		if err := json.Unmarshal(trimmed, &profiles); err != nil {
+			return nil, err
+		}
+		return profiles, nil
+	}
+	return parseINICredentials(data)
+}
+
+func parseINICredentials(data []byte) (map[string]Profile, error) {
+	profiles := map[string]Profile{}
+	var section string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[section]; !ok {
+				profiles[section] = Profile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNum)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: key outside of a [profile] section", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		p := profiles[section]
+		switch key {
+		case "host":
+			p.Host = value
+		case "token":
+			p.Token = value
+		case "token_file":
+			p.TokenFile = value
+		case "ca_cert_file":
+			p.CACertFile = value
+		case "ca_cert_pem":
+			p.CACertPEM = value
+		case "client_cert":
+			p.ClientCert = value
+		case "client_key":
+			p.ClientKey = value
+		case "tls_server_name":
+			p.TLSServerName = value
+		case "insecure_skip_verify":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid insecure_skip_verify value %q", lineNum, value)
+			}
+			p.InsecureSkipVerify = b
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", lineNum, key)
+		}
+		profiles[section] = p
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
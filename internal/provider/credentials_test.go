@@ -0,0 +1,209 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testINICredentials = `
+# Orchestrators
+[default]
+host = https://default.example.com
+token = default-token
+
+[lab]
+host = "https://lab.example.com"
+token = lab-token
+ca_cert_file = /etc/blastshield/lab-ca.pem
+insecure_skip_verify = true
+`
+
+const testJSONCredentials = `{
+	"default": {"host": "https://default.example.com", "token": "default-token"},
+	"lab": {
+		"host": "https://lab.example.com",
+		"token": "lab-token",
+		"ca_cert_file": "/etc/blastshield/lab-ca.pem",
+		"insecure_skip_verify": true
+	}
+}`
+
+func writeCredentials(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// clearConnectionEnv unsets the variables ConnectionFromEnv reads so the developer's
+// own environment does not leak into the tests.
+func clearConnectionEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		"BLASTSHIELD_HOST", "BLASTSHIELD_TOKEN", "BLASTSHIELD_TOKEN_FILE", "BLASTSHIELD_PROFILE", "BLASTSHIELD_CREDENTIALS_FILE",
+		"BLASTSHIELD_CA_CERT_FILE", "BLASTSHIELD_CA_CERT_PEM", "BLASTSHIELD_CLIENT_CERT", "BLASTSHIELD_CLIENT_KEY",
+		"BLASTSHIELD_TLS_SERVER_NAME", "BLASTSHIELD_INSECURE_SKIP_VERIFY", "BLASTSHIELD_HTTP_PROXY", "BLASTSHIELD_NO_PROXY",
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestLoadProfile_Formats(t *testing.T) {
+	for name, content := range map[string]string{"ini": testINICredentials, "json": testJSONCredentials} {
+		t.Run(name, func(t *testing.T) {
+			file := writeCredentials(t, content)
+
+			profile, err := LoadProfile(file, "lab")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if profile.Host != "https://lab.example.com" || profile.Token != "lab-token" {
+				t.Errorf("unexpected host or token: %+v", profile)
+			}
+			if profile.CACertFile != "/etc/blastshield/lab-ca.pem" || !profile.InsecureSkipVerify {
+				t.Errorf("unexpected TLS settings: %+v", profile)
+			}
+
+			profile, err = LoadProfile(file, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if profile == nil || profile.Host != "https://default.example.com" {
+				t.Errorf("expected the default profile, got %+v", profile)
+			}
+		})
+	}
+}
+
+func TestLoadProfile_Missing(t *testing.T) {
+	file := writeCredentials(t, testINICredentials)
+
+	if _, err := LoadProfile(file, "prod"); err == nil {
+		t.Error("expected an error for a profile that does not exist")
+	}
+
+	missing := filepath.Join(t.TempDir(), "credentials")
+	if profile, err := LoadProfile(missing, ""); err != nil || profile != nil {
+		t.Errorf("expected no profile and no error without a file, got %+v, %v", profile, err)
+	}
+	if _, err := LoadProfile(missing, "lab"); err == nil {
+		t.Error("expected an error for a named profile without a file")
+	}
+}
+
+func TestLoadProfile_InvalidINI(t *testing.T) {
+	cases := map[string]string{
+		"no section":  "host = https://example.com\n",
+		"unknown key": "[default]\nhots = https://example.com\n",
+		"not a pair":  "[default]\nhost\n",
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadProfile(writeCredentials(t, content), ""); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestConnectionFromEnv_Precedence(t *testing.T) {
+	clearConnectionEnv(t)
+	t.Setenv("BLASTSHIELD_CREDENTIALS_FILE", writeCredentials(t, testINICredentials))
+
+	conn, err := ConnectionFromEnv("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://default.example.com" {
+		t.Errorf("expected the default profile, got %q", conn.Host)
+	}
+
+	t.Setenv("BLASTSHIELD_PROFILE", "lab")
+	conn, err = ConnectionFromEnv("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://lab.example.com" || conn.Transport.CACertFile != "/etc/blastshield/lab-ca.pem" {
+		t.Errorf("expected the profile from BLASTSHIELD_PROFILE, got %+v", conn)
+	}
+
+	conn, err = ConnectionFromEnv("default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://default.example.com" {
+		t.Errorf("expected the profile argument to win over BLASTSHIELD_PROFILE, got %q", conn.Host)
+	}
+
+	t.Setenv("BLASTSHIELD_PROFILE", "")
+	t.Setenv("BLASTSHIELD_HOST", "https://env.example.com")
+	t.Setenv("BLASTSHIELD_TOKEN", "env-token")
+	t.Setenv("BLASTSHIELD_CA_CERT_FILE", "/tmp/env-ca.pem")
+	conn, err = ConnectionFromEnv("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://env.example.com" || conn.Token != "env-token" || conn.Transport.CACertFile != "/tmp/env-ca.pem" {
+		t.Errorf("expected environment variables to win over the default profile, got %+v", conn)
+	}
+
+	t.Setenv("BLASTSHIELD_TOKEN", "")
+	t.Setenv("BLASTSHIELD_TOKEN_FILE", "/var/run/secrets/blastshield/token")
+	conn, err = ConnectionFromEnv("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Token != "" || conn.TokenFile != "/var/run/secrets/blastshield/token" {
+		t.Errorf("expected BLASTSHIELD_TOKEN_FILE to replace the profile token, got %+v", conn)
+	}
+}
+
+func TestConnectionFromEnv_ExplicitProfile(t *testing.T) {
+	clearConnectionEnv(t)
+	t.Setenv("BLASTSHIELD_CREDENTIALS_FILE", writeCredentials(t, testINICredentials+`
+[partial]
+tls_server_name = orchestrator.internal
+`))
+	t.Setenv("BLASTSHIELD_HOST", "https://env.example.com")
+	t.Setenv("BLASTSHIELD_TOKEN_FILE", "/var/run/secrets/blastshield/token")
+	t.Setenv("BLASTSHIELD_CA_CERT_FILE", "/tmp/env-ca.pem")
+
+	conn, err := ConnectionFromEnv("lab")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://lab.example.com" || conn.Token != "lab-token" || conn.TokenFile != "" {
+		t.Errorf("expected the named profile to win over the environment, got %+v", conn)
+	}
+	if conn.Transport.CACertFile != "/etc/blastshield/lab-ca.pem" {
+		t.Errorf("expected the profile CA certificate, got %q", conn.Transport.CACertFile)
+	}
+
+	conn, err = ConnectionFromEnv("partial")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.Host != "https://env.example.com" || conn.TokenFile != "/var/run/secrets/blastshield/token" {
+		t.Errorf("expected the environment to fill settings the profile leaves unset, got %+v", conn)
+	}
+	if conn.Transport.TLSServerName != "orchestrator.internal" || conn.Transport.CACertFile != "/tmp/env-ca.pem" {
+		t.Errorf("unexpected TLS settings: %+v", conn.Transport)
+	}
+}
@@ -0,0 +1,789 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Terraform asks for the resource and data source schemas before any provider
+// instance is configured, and aliased provider blocks may point at orchestrators
+// running different API versions. So, unless a version is pinned, the provider
+// registers the union of the resource types of every compiled version, each with
+// the schema of the newest version that has it. Configure then selects the version
+// for its orchestrator, and each resource delegates to that version's
+// implementation, converting values between the two schemas where they differ.
+//
+// Attributes that newer versions removed stay in the registered schema with the
+// deprecation message of the last version that has them, so configurations that
+// still set them get a warning instead of an "Unsupported argument" error.
+
+const providerTypeName = "blastshield"
+
+// boundClient is the provider data of a provider instance whose API version was
+// selected in Configure. It embeds the Client, which the generated resources use
+// unchanged.
+type boundClient struct {
+	*Client
+	apiVersion   string
+	capabilities versions.CapabilitySet
+	resources    map[string]func() resource.Resource
+	dataSources  map[string]func() datasource.DataSource
+}
+
+func bindVersion(client *Client, apiVersion string, vp versions.VersionedProvider) *boundClient {
+	return &boundClient{
+		Client:       client,
+		apiVersion:   apiVersion,
+		capabilities: vp.Capabilities(),
+		resources:    resourceTypes(vp.Resources()),
+		dataSources:  dataSourceTypes(vp.DataSources()),
+	}
+}
+
+// resourceTypes indexes resource factories by type name.
+func resourceTypes(factories []func() resource.Resource) map[string]func() resource.Resource {
+	types := make(map[string]func() resource.Resource, len(factories))
+	for _, f := range factories {
+		var resp resource.MetadataResponse
+		f().Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+		types[resp.TypeName] = f
+	}
+	return types
+}
+
+// dataSourceTypes indexes data source factories by type name.
+func dataSourceTypes(factories []func() datasource.DataSource) map[string]func() datasource.DataSource {
+	types := make(map[string]func() datasource.DataSource, len(factories))
+	for _, f := range factories {
+		var resp datasource.MetadataResponse
+		f().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: providerTypeName}, &resp)
+		types[resp.TypeName] = f
+	}
+	return types
+}
+
+// allResources returns a versionedResource for every resource type of every
+// compiled version.
+func allResources() []func() resource.Resource {
+	sources := map[string][]func() resource.Resource{}
+	for _, ver := range versions.Versions() {
+		vp, _ := versions.Lookup(ver)
+		for name, f := range resourceTypes(vp.Resources()) {
+			sources[name] = append(sources[name], f)
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	factories := make([]func() resource.Resource, 0, len(names))
+	for _, name := range names {
+		older, schemaSource := sources[name][:len(sources[name])-1], sources[name][len(sources[name])-1]
+		factories = append(factories, func() resource.Resource {
+			return &versionedResource{typeName: name, schemaSource: schemaSource, olderSources: older}
+		})
+	}
+	return factories
+}
+
+// allDataSources returns a versionedDataSource for every data source type of every
+// compiled version.
+func allDataSources() []func() datasource.DataSource {
+	sources := map[string][]func() datasource.DataSource{}
+	for _, ver := range versions.Versions() {
+		vp, _ := versions.Lookup(ver)
+		for name, f := range dataSourceTypes(vp.DataSources()) {
+			sources[name] = append(sources[name], f)
+		}
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	factories := make([]func() datasource.DataSource, 0, len(names))
+	for _, name := range names {
+		older, schemaSource := sources[name][:len(sources[name])-1], sources[name][len(sources[name])-1]
+		factories = append(factories, func() datasource.DataSource {
+			return &versionedDataSource{typeName: name, schemaSource: schemaSource, olderSources: older}
+		})
+	}
+	return factories
+}
+
+// mergeAttributes returns attrs with the attributes only older has added, also
+// within nested attributes. An added attribute is made optional, since the newer
+// version does not require it. An attribute both versions have keeps the newer
+// type; convertValue converts retyped strings, numbers and bools.
+func mergeAttributes[A any](attrs, older map[string]A) map[string]A {
+	merged := make(map[string]A, len(attrs))
+	for name, a := range attrs {
+		merged[name] = a
+	}
+	for name, o := range older {
+		a, ok := merged[name]
+		if !ok {
+			merged[name] = optional(o)
+			continue
+		}
+		newer, prior := nestedAttributes[A](a), nestedAttributes[A](o)
+		if newer != nil && prior != nil {
+			merged[name] = setNestedAttributes(a, mergeAttributes(newer, prior))
+		}
+	}
+	return merged
+}
+
+// nestedAttributes returns the attributes of a nested attribute, or nil for
+// other attributes. Single nested attributes hold them in Attributes and list,
+// set and map nested attributes in NestedObject.Attributes.
+func nestedAttributes[A any](a A) map[string]A {
+	v := reflect.ValueOf(a)
+	if f := v.FieldByName("NestedObject"); f.IsValid() {
+		v = f
+	}
+	if f := v.FieldByName("Attributes"); f.IsValid() {
+		attrs, _ := f.Interface().(map[string]A)
+		return attrs
+	}
+	return nil
+}
+
+func setNestedAttributes[A any](a A, attrs map[string]A) A {
+	v := copyAttribute(a)
+	target := v
+	if f := v.FieldByName("NestedObject"); f.IsValid() {
+		target = f
+	}
+	target.FieldByName("Attributes").Set(reflect.ValueOf(attrs))
+	return v.Interface().(A)
+}
+
+// optional returns a with Required replaced by Optional.
+func optional[A any](a A) A {
+	v := copyAttribute(a)
+	if required := v.FieldByName("Required"); required.IsValid() && required.Bool() {
+		required.SetBool(false)
+		v.FieldByName("Optional").SetBool(true)
+	}
+	return v.Interface().(A)
+}
+
+// copyAttribute returns a settable copy of the attribute struct a holds.
+func copyAttribute[A any](a A) reflect.Value {
+	v := reflect.New(reflect.TypeOf(a)).Elem()
+	v.Set(reflect.ValueOf(a))
+	return v
+}
+
+// schemaConverter converts values between the registered schema of a type and the
+// schema of the version selected in Configure.
+type schemaConverter struct {
+	outer, inner tftypes.Type
+}
+
+func (c schemaConverter) same() bool {
+	return c.outer.Equal(c.inner)
+}
+
+func (c schemaConverter) toInner(v tftypes.Value, diags *diag.Diagnostics) tftypes.Value {
+	return c.convert(v, c.inner, diags)
+}
+
+func (c schemaConverter) toOuter(v tftypes.Value, diags *diag.Diagnostics) tftypes.Value {
+	return c.convert(v, c.outer, diags)
+}
+
+func (c schemaConverter) convert(v tftypes.Value, typ tftypes.Type, diags *diag.Diagnostics) tftypes.Value {
+	if c.same() {
+		return v
+	}
+	converted, err := convertValue(v, typ)
+	if err != nil {
+		diags.AddError("Schema Conversion Error", "The provider cannot convert between API version schemas: "+err.Error())
+		return tftypes.NewValue(typ, nil)
+	}
+	return converted
+}
+
+// convertValue converts v to typ. Object attributes typ does not have are dropped
+// and those v does not have are set to null. Strings, numbers and bools convert
+// into each other where the value allows it.
+func convertValue(v tftypes.Value, typ tftypes.Type) (tftypes.Value, error) {
+	if v.Type().Equal(typ) {
+		return v, nil
+	}
+	if !v.IsKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+	if v.IsNull() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	switch t := typ.(type) {
+	case tftypes.Object:
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return tftypes.Value{}, err
+		}
+		converted := make(map[string]tftypes.Value, len(t.AttributeTypes))
+		for name, attrType := range t.AttributeTypes {
+			attr, ok := attrs[name]
+			if !ok {
+				converted[name] = tftypes.NewValue(attrType, nil)
+				continue
+			}
+			c, err := convertValue(attr, attrType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", name, err)
+			}
+			converted[name] = c
+		}
+		return tftypes.NewValue(t, converted), nil
+	case tftypes.List:
+		elems, err := convertElements(v, t.ElementType)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(t, elems), nil
+	case tftypes.Set:
+		elems, err := convertElements(v, t.ElementType)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(t, elems), nil
+	case tftypes.Map:
+		var elems map[string]tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return tftypes.Value{}, err
+		}
+		for k, e := range elems {
+			c, err := convertValue(e, t.ElementType)
+			if err != nil {
+				return tftypes.Value{}, fmt.Errorf("%s: %w", k, err)
+			}
+			elems[k] = c
+		}
+		return tftypes.NewValue(t, elems), nil
+	}
+	if isPrimitive(v.Type()) && isPrimitive(typ) {
+		return convertPrimitive(v, typ)
+	}
+	return tftypes.Value{}, fmt.Errorf("cannot convert %s to %s", v.Type(), typ)
+}
+
+func isPrimitive(typ tftypes.Type) bool {
+	return typ.Is(tftypes.String) || typ.Is(tftypes.Number) || typ.Is(tftypes.Bool)
+}
+
+// convertPrimitive converts a string, number or bool an API version retyped
+// through its string form, the way stateupgrade converts retyped state.
+func convertPrimitive(v tftypes.Value, typ tftypes.Type) (tftypes.Value, error) {
+	var s string
+	switch {
+	case v.Type().Is(tftypes.String):
+		if err := v.As(&s); err != nil {
+			return tftypes.Value{}, err
+		}
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err != nil {
+			return tftypes.Value{}, err
+		}
+		s = n.Text('f', -1)
+	default:
+		var b bool
+		if err := v.As(&b); err != nil {
+			return tftypes.Value{}, err
+		}
+		s = strconv.FormatBool(b)
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		return tftypes.NewValue(typ, s), nil
+	case typ.Is(tftypes.Number):
+		n, ok := new(big.Float).SetString(s)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("cannot convert %s %q to %s", v.Type(), s, typ)
+		}
+		return tftypes.NewValue(typ, n), nil
+	default:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("cannot convert %s %q to %s", v.Type(), s, typ)
+		}
+		return tftypes.NewValue(typ, b), nil
+	}
+}
+
+func convertElements(v tftypes.Value, elemType tftypes.Type) ([]tftypes.Value, error) {
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+	for i, e := range elems {
+		c, err := convertValue(e, elemType)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		elems[i] = c
+	}
+	return elems, nil
+}
+
+var (
+	_ resource.Resource                 = &versionedResource{}
+	_ resource.ResourceWithConfigure    = &versionedResource{}
+	_ resource.ResourceWithImportState  = &versionedResource{}
+	_ resource.ResourceWithModifyPlan   = &versionedResource{}
+	_ resource.ResourceWithUpgradeState = &versionedResource{}
+)
+
+// versionedResource is a resource type registered for every provider instance. It
+// delegates to the implementation of the API version selected in Configure.
+type versionedResource struct {
+	typeName     string
+	schemaSource func() resource.Resource
+	// olderSources are the implementations of the older versions with the type,
+	// oldest first. Their removed attributes are merged into the schema.
+	olderSources []func() resource.Resource
+
+	apiVersion   string
+	capabilities versions.CapabilitySet
+	inner        resource.Resource
+	outerSchema  resourceschema.Schema
+	innerSchema  resourceschema.Schema
+	conv         schemaConverter
+}
+
+func resourceSchema(ctx context.Context, r resource.Resource) resourceschema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func (r *versionedResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.typeName
+}
+
+func (r *versionedResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = r.schema(ctx)
+}
+
+// schema returns the schema of the schema source with the attributes of older
+// versions it lacks.
+func (r *versionedResource) schema(ctx context.Context) resourceschema.Schema {
+	s := resourceSchema(ctx, r.schemaSource())
+	for i := len(r.olderSources) - 1; i >= 0; i-- {
+		s.Attributes = mergeAttributes(s.Attributes, resourceSchema(ctx, r.olderSources[i]()).Attributes)
+	}
+	return s
+}
+
+// UpgradeState returns the state upgraders of the schema source. Terraform stores
+// state in its schema, whichever API version the provider instance selects.
+func (r *versionedResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	u, ok := r.schemaSource().(resource.ResourceWithUpgradeState)
+	if !ok {
+		return nil
+	}
+	upgraders := u.UpgradeState(ctx)
+	for prior, upgrader := range upgraders {
+		upgrade := upgrader.StateUpgrader
+		if upgrade == nil {
+			continue
+		}
+		// The upgraded state has the schema source's type, which lacks the
+		// attributes merged from older versions
+		upgrader.StateUpgrader = func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			upgrade(ctx, req, resp)
+			if resp.Diagnostics.HasError() || resp.State.Raw.Type() == nil {
+				return
+			}
+			conv := schemaConverter{outer: r.schema(ctx).Type().TerraformType(ctx), inner: resp.State.Raw.Type()}
+			resp.State.Raw = conv.toOuter(resp.State.Raw, &resp.Diagnostics)
+		}
+		upgraders[prior] = upgrader
+	}
+	return upgraders
+}
+
+func (r *versionedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*boundClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *boundClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+	factory, ok := client.resources[r.typeName]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unsupported Resource Type",
+			fmt.Sprintf("%s is not available in Blastshield API version %s.", r.typeName, client.apiVersion),
+		)
+		return
+	}
+
+	r.apiVersion = client.apiVersion
+	r.capabilities = client.capabilities
+	r.inner = factory()
+	if c, ok := r.inner.(resource.ResourceWithConfigure); ok {
+		c.Configure(ctx, req, resp)
+	}
+	r.outerSchema = r.schema(ctx)
+	r.innerSchema = resourceSchema(ctx, r.inner)
+	r.conv = schemaConverter{
+		outer: r.outerSchema.Type().TerraformType(ctx),
+		inner: r.innerSchema.Type().TerraformType(ctx),
+	}
+}
+
+func (r *versionedResource) configured(diags *diag.Diagnostics) bool {
+	if r.inner == nil {
+		diags.AddError("Unconfigured Resource", fmt.Sprintf("%s was used before the provider was configured.", r.typeName))
+		return false
+	}
+	return true
+}
+
+func (r *versionedResource) innerConfig(c tfsdk.Config, diags *diag.Diagnostics) tfsdk.Config {
+	return tfsdk.Config{Raw: r.conv.toInner(c.Raw, diags), Schema: r.innerSchema}
+}
+
+func (r *versionedResource) innerPlan(p tfsdk.Plan, diags *diag.Diagnostics) tfsdk.Plan {
+	return tfsdk.Plan{Raw: r.conv.toInner(p.Raw, diags), Schema: r.innerSchema}
+}
+
+func (r *versionedResource) innerState(s tfsdk.State, diags *diag.Diagnostics) tfsdk.State {
+	return tfsdk.State{Raw: r.conv.toInner(s.Raw, diags), Schema: r.innerSchema}
+}
+
+func (r *versionedResource) outerPlan(p tfsdk.Plan, diags *diag.Diagnostics) tfsdk.Plan {
+	return tfsdk.Plan{Raw: r.conv.toOuter(p.Raw, diags), Schema: r.outerSchema}
+}
+
+func (r *versionedResource) outerState(s tfsdk.State, diags *diag.Diagnostics) tfsdk.State {
+	return tfsdk.State{Raw: r.conv.toOuter(s.Raw, diags), Schema: r.outerSchema}
+}
+
+func (r *versionedResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.inner == nil {
+		return
+	}
+	r.rejectUnsupportedAttributes(req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, ok := r.inner.(resource.ResourceWithModifyPlan)
+	if !ok {
+		return
+	}
+
+	innerReq := resource.ModifyPlanRequest{
+		Config:       r.innerConfig(req.Config, &resp.Diagnostics),
+		Plan:         r.innerPlan(req.Plan, &resp.Diagnostics),
+		State:        r.innerState(req.State, &resp.Diagnostics),
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := &resource.ModifyPlanResponse{
+		Plan:            r.innerPlan(resp.Plan, &resp.Diagnostics),
+		RequiresReplace: resp.RequiresReplace,
+		Private:         resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	m.ModifyPlan(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Plan = r.outerPlan(innerResp.Plan, &resp.Diagnostics)
+	resp.RequiresReplace = innerResp.RequiresReplace
+	resp.Private = innerResp.Private
+}
+
+// rejectUnsupportedAttributes reports the attributes set in config that the selected
+// API version does not support. Converting to the inner schema would drop them.
+func (r *versionedResource) rejectUnsupportedAttributes(config tfsdk.Config, diags *diag.Diagnostics) {
+	name := strings.TrimPrefix(r.typeName, providerTypeName+"_")
+	if !r.capabilities.HasResource(name) || !config.Raw.IsKnown() || config.Raw.IsNull() {
+		return
+	}
+	var attrs map[string]tftypes.Value
+	if err := config.Raw.As(&attrs); err != nil {
+		return
+	}
+	for attr, v := range attrs {
+		if !v.IsNull() && !r.capabilities.HasAttribute(name, attr) {
+			diags.AddAttributeError(
+				path.Root(attr),
+				"Unsupported Attribute",
+				r.capabilities.Unsupported(fmt.Sprintf("Attribute %s of %s", attr, r.typeName)).Error()+".",
+			)
+		}
+	}
+}
+
+func (r *versionedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	innerReq := resource.CreateRequest{
+		Config:       r.innerConfig(req.Config, &resp.Diagnostics),
+		Plan:         r.innerPlan(req.Plan, &resp.Diagnostics),
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := &resource.CreateResponse{
+		State:   r.innerState(resp.State, &resp.Diagnostics),
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.inner.Create(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = r.outerState(innerResp.State, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+func (r *versionedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	innerReq := resource.ReadRequest{
+		State:        r.innerState(req.State, &resp.Diagnostics),
+		Private:      req.Private,
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := &resource.ReadResponse{
+		State:   r.innerState(resp.State, &resp.Diagnostics),
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.inner.Read(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = r.outerState(innerResp.State, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+func (r *versionedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	innerReq := resource.UpdateRequest{
+		Config:       r.innerConfig(req.Config, &resp.Diagnostics),
+		Plan:         r.innerPlan(req.Plan, &resp.Diagnostics),
+		State:        r.innerState(req.State, &resp.Diagnostics),
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := &resource.UpdateResponse{
+		State:   r.innerState(resp.State, &resp.Diagnostics),
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.inner.Update(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = r.outerState(innerResp.State, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+func (r *versionedResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+
+	innerReq := resource.DeleteRequest{
+		State:        r.innerState(req.State, &resp.Diagnostics),
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}
+	innerResp := &resource.DeleteResponse{
+		State:   r.innerState(resp.State, &resp.Diagnostics),
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.inner.Delete(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = r.outerState(innerResp.State, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+func (r *versionedResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !r.configured(&resp.Diagnostics) {
+		return
+	}
+	i, ok := r.inner.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			fmt.Sprintf("%s does not support import in Blastshield API version %s.", r.typeName, r.apiVersion),
+		)
+		return
+	}
+
+	innerResp := &resource.ImportStateResponse{
+		State:   r.innerState(resp.State, &resp.Diagnostics),
+		Private: resp.Private,
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	i.ImportState(ctx, req, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = r.outerState(innerResp.State, &resp.Diagnostics)
+	resp.Private = innerResp.Private
+}
+
+var (
+	_ datasource.DataSource              = &versionedDataSource{}
+	_ datasource.DataSourceWithConfigure = &versionedDataSource{}
+)
+
+// versionedDataSource is the data source counterpart of versionedResource.
+type versionedDataSource struct {
+	typeName     string
+	schemaSource func() datasource.DataSource
+	olderSources []func() datasource.DataSource
+
+	inner       datasource.DataSource
+	outerSchema datasourceschema.Schema
+	innerSchema datasourceschema.Schema
+	conv        schemaConverter
+}
+
+func dataSourceSchema(ctx context.Context, d datasource.DataSource) datasourceschema.Schema {
+	var resp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+func (d *versionedDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = d.typeName
+}
+
+func (d *versionedDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = d.schema(ctx)
+}
+
+// schema returns the schema of the schema source with the attributes of older
+// versions it lacks.
+func (d *versionedDataSource) schema(ctx context.Context) datasourceschema.Schema {
+	s := dataSourceSchema(ctx, d.schemaSource())
+	for i := len(d.olderSources) - 1; i >= 0; i-- {
+		s.Attributes = mergeAttributes(s.Attributes, dataSourceSchema(ctx, d.olderSources[i]()).Attributes)
+	}
+	return s
+}
+
+func (d *versionedDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*boundClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *boundClient, got: %T", req.ProviderData),
+		)
+		return
+	}
+	factory, ok := client.dataSources[d.typeName]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unsupported Data Source Type",
+			fmt.Sprintf("%s is not available in Blastshield API version %s.", d.typeName, client.apiVersion),
+		)
+		return
+	}
+
+	d.inner = factory()
+	if c, ok := d.inner.(datasource.DataSourceWithConfigure); ok {
+		c.Configure(ctx, req, resp)
+	}
+	d.outerSchema = d.schema(ctx)
+	d.innerSchema = dataSourceSchema(ctx, d.inner)
+	d.conv = schemaConverter{
+		outer: d.outerSchema.Type().TerraformType(ctx),
+		inner: d.innerSchema.Type().TerraformType(ctx),
+	}
+}
+
+func (d *versionedDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.inner == nil {
+		resp.Diagnostics.AddError("Unconfigured Data Source", fmt.Sprintf("%s was used before the provider was configured.", d.typeName))
+		return
+	}
+
+	innerReq := datasource.ReadRequest{
+		Config:       tfsdk.Config{Raw: d.conv.toInner(req.Config.Raw, &resp.Diagnostics), Schema: d.innerSchema},
+		ProviderMeta: req.ProviderMeta,
+	}
+	innerResp := &datasource.ReadResponse{
+		State: tfsdk.State{Raw: d.conv.toInner(resp.State.Raw, &resp.Diagnostics), Schema: d.innerSchema},
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	d.inner.Read(ctx, innerReq, innerResp)
+
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.State = tfsdk.State{Raw: d.conv.toOuter(innerResp.State.Raw, &resp.Diagnostics), Schema: d.outerSchema}
+}
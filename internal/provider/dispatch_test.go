@@ -0,0 +1,615 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/dynamic"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConvertValue_Object(t *testing.T) {
+	ruleType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}
+	newRuleType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number, "proto": tftypes.String}}
+	from := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":  tftypes.String,
+		"extra": tftypes.Bool,
+		"rules": tftypes.Set{ElementType: ruleType},
+	}}
+	to := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":    tftypes.String,
+		"comment": tftypes.String,
+		"rules":   tftypes.Set{ElementType: newRuleType},
+	}}
+
+	v := tftypes.NewValue(from, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "web"),
+		"extra": tftypes.NewValue(tftypes.Bool, true),
+		"rules": tftypes.NewValue(tftypes.Set{ElementType: ruleType}, []tftypes.Value{
+			tftypes.NewValue(ruleType, map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, 443)}),
+		}),
+	})
+
+	got, err := convertValue(v, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := tftypes.NewValue(to, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, "web"),
+		"comment": tftypes.NewValue(tftypes.String, nil),
+		"rules": tftypes.NewValue(tftypes.Set{ElementType: newRuleType}, []tftypes.Value{
+			tftypes.NewValue(newRuleType, map[string]tftypes.Value{
+				"port":  tftypes.NewValue(tftypes.Number, 443),
+				"proto": tftypes.NewValue(tftypes.String, nil),
+			}),
+		}),
+	})
+	if !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestConvertValue_UnknownAndIncompatible(t *testing.T) {
+	to := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+
+	got, err := convertValue(tftypes.NewValue(tftypes.Object{}, tftypes.UnknownValue), to)
+	if err != nil || got.IsKnown() || !got.Type().Equal(to) {
+		t.Errorf("expected an unknown %s, got %s (%v)", to, got, err)
+	}
+
+	if _, err := convertValue(tftypes.NewValue(tftypes.String, "x"), tftypes.Number); err == nil {
+		t.Error("expected an error converting a string to a number")
+	}
+}
+
+func TestConvertValue_Primitives(t *testing.T) {
+	tests := []struct {
+		from, want tftypes.Value
+	}{
+		{tftypes.NewValue(tftypes.String, "443"), tftypes.NewValue(tftypes.Number, 443)},
+		{tftypes.NewValue(tftypes.Number, 1.5), tftypes.NewValue(tftypes.String, "1.5")},
+		{tftypes.NewValue(tftypes.String, "true"), tftypes.NewValue(tftypes.Bool, true)},
+		{tftypes.NewValue(tftypes.Bool, false), tftypes.NewValue(tftypes.String, "false")},
+		{tftypes.NewValue(tftypes.Number, 1), tftypes.NewValue(tftypes.Bool, true)},
+	}
+	for _, tt := range tests {
+		got, err := convertValue(tt.from, tt.want.Type())
+		if err != nil {
+			t.Errorf("converting %s: unexpected error: %v", tt.from, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("converting %s: expected %s, got %s", tt.from, tt.want, got)
+		}
+	}
+
+	if _, err := convertValue(tftypes.NewValue(tftypes.Bool, true), tftypes.Number); err == nil {
+		t.Error("expected an error converting a bool to a number")
+	}
+}
+
+func TestSelectAPIVersion(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+
+	tests := map[string]struct {
+		status      int
+		body        string
+		wantVersion string
+		wantError   bool
+	}{
+		"server version":       {http.StatusOK, `{"info": {"version": "` + latest + `"}}`, latest, false},
+		"unsupported version":  {http.StatusOK, `{"info": {"version": "0.0.1"}}`, "", true},
+		"unreachable fallback": {http.StatusForbidden, `{"detail": "no"}`, latest, false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/openapi.json" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+
+			vp, ver, serverVersion, diags := selectAPIVersion(context.Background(), client, versionOptions{})
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("expected error %v, got %v", tc.wantError, diags)
+			}
+			if ver != tc.wantVersion {
+				t.Errorf("expected version %q, got %q", tc.wantVersion, ver)
+			}
+			if !tc.wantError && vp == nil {
+				t.Error("expected a versioned provider")
+			}
+			if detected := tc.status == http.StatusOK && !tc.wantError; detected != (serverVersion != "") {
+				t.Errorf("expected a detected server version only from the server, got %q", serverVersion)
+			}
+			if tc.status != http.StatusOK && (diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "API Version Not Detected") {
+				t.Errorf("expected a fallback warning, got %v", diags)
+			}
+		})
+	}
+}
+
+func TestAPIVersion_SingleAttempt(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.RetryMaxWait = time.Minute
+
+	start := time.Now()
+	if _, err := client.APIVersion(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the probe to give up without waiting to retry, took %s", elapsed)
+	}
+}
+
+func TestSelectAPIVersion_Override(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, ver, serverVersion, diags := selectAPIVersion(context.Background(), client, versionOptions{override: latest})
+	if diags.HasError() || ver != latest || serverVersion != "" {
+		t.Errorf("expected version %s without detection, got %q (%v)", latest, ver, diags)
+	}
+
+	if _, _, _, diags := selectAPIVersion(context.Background(), client, versionOptions{override: "0.0.1"}); !diags.HasError() {
+		t.Error("expected an error for an unsupported configured version")
+	}
+}
+
+func TestSelectAPIVersion_UsesCache(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"info": {"version": "` + latest + `"}}`))
+	})
+	now := time.Now()
+	cache := &versionCache{
+		path: filepath.Join(t.TempDir(), "cache", "api-versions.json"),
+		ttl:  time.Hour,
+		now:  func() time.Time { return now },
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, ver, _, diags := selectAPIVersion(context.Background(), client, versionOptions{cache: cache}); diags.HasError() || ver != latest {
+			t.Fatalf("expected version %s, got %q (%v)", latest, ver, diags)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the second selection to use the cache, got %d requests", calls.Load())
+	}
+
+	// Expired entries are detected again
+	now = now.Add(2 * time.Hour)
+	selectAPIVersion(context.Background(), client, versionOptions{cache: cache})
+	if calls.Load() != 2 {
+		t.Errorf("expected an expired entry to be fetched again, got %d requests", calls.Load())
+	}
+}
+
+func TestSelectAPIVersion_Constraint(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"info": {"version": "` + latest + `"}}`))
+	})
+
+	tests := map[string]struct {
+		constraint string
+		wantError  string
+	}{
+		"met":     {">= " + latest, ""},
+		"not met": {"> " + latest, "API Version Constraint Not Met"},
+		"invalid": {"about 1.13", "Invalid API Version Constraint"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, diags := selectAPIVersion(context.Background(), client, versionOptions{constraint: tc.constraint})
+			if tc.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != tc.wantError {
+				t.Errorf("expected %q, got %v", tc.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestSelectAPIVersion_ConstraintOnFallback(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, ver, _, diags := selectAPIVersion(context.Background(), client, versionOptions{constraint: ">= " + latest})
+	if diags.HasError() || ver != latest {
+		t.Fatalf("expected fallback version %s, got %q (%v)", latest, ver, diags)
+	}
+
+	vp, _, _, diags := selectAPIVersion(context.Background(), client, versionOptions{constraint: "> " + latest})
+	if vp != nil || diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "API Version Constraint Not Met" {
+		t.Errorf("expected the fallback version to fail the constraint, got %v", diags)
+	}
+}
+
+func TestSelectAPIVersion_WarnsWhenServerIsNewer(t *testing.T) {
+	vp, latest := versions.LatestVersion()
+	if vp == nil {
+		t.Skip("no API versions compiled in")
+	}
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"info": {"version": "99.0.0"}}`))
+	})
+
+	_, ver, _, diags := selectAPIVersion(context.Background(), client, versionOptions{})
+	if diags.HasError() || ver != latest {
+		t.Fatalf("expected version %s, got %q (%v)", latest, ver, diags)
+	}
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Newer API Version" {
+		t.Errorf("expected a newer version warning, got %v", diags)
+	}
+}
+
+func TestVersionCache_PerHost(t *testing.T) {
+	cache := &versionCache{path: filepath.Join(t.TempDir(), "api-versions.json"), ttl: time.Hour, now: time.Now}
+
+	if err := cache.put("https://a.example.com", "1.13.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cache.put("https://b.example.com", "1.14.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ver, ok := cache.get("https://a.example.com"); !ok || ver != "1.13.0" {
+		t.Errorf("expected 1.13.0 for host a, got %q", ver)
+	}
+	if ver, ok := cache.get("https://b.example.com"); !ok || ver != "1.14.0" {
+		t.Errorf("expected 1.14.0 for host b, got %q", ver)
+	}
+	if _, ok := cache.get("https://c.example.com"); ok {
+		t.Error("did not expect an entry for host c")
+	}
+
+	var nilCache *versionCache
+	if _, ok := nilCache.get("https://a.example.com"); ok {
+		t.Error("expected a nil cache to be empty")
+	}
+}
+
+func TestVersionedResource_BindsSelectedVersion(t *testing.T) {
+	vp, latest := versions.LatestVersion()
+	if vp == nil {
+		t.Skip("no API versions compiled in")
+	}
+
+	var node resource.Resource
+	for _, f := range allResources() {
+		r := f()
+		var meta resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: providerTypeName}, &meta)
+		if meta.TypeName == "blastshield_node" {
+			node = r
+		}
+	}
+	if node == nil {
+		t.Fatal("expected blastshield_node among the registered resources")
+	}
+
+	var resp resource.ConfigureResponse
+	bound := bindVersion(NewClient("http://localhost", "token"), latest, vp)
+	node.(resource.ResourceWithConfigure).Configure(context.Background(), resource.ConfigureRequest{ProviderData: bound}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	v := node.(*versionedResource)
+	want := resourceSchema(context.Background(), resourceTypes(vp.Resources())["blastshield_node"]())
+	if v.inner == nil || !reflect.DeepEqual(v.innerSchema, want) {
+		t.Errorf("expected node to delegate to version %s", latest)
+	}
+
+	delete(bound.resources, "blastshield_node")
+	resp = resource.ConfigureResponse{}
+	(&versionedResource{typeName: "blastshield_node"}).Configure(context.Background(), resource.ConfigureRequest{ProviderData: bound}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a resource type the selected version lacks")
+	}
+}
+
+type upgradingResource struct {
+	resource.Resource
+}
+
+func (upgradingResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{0: {}}
+}
+
+func TestVersionedResource_UpgradeState(t *testing.T) {
+	r := &versionedResource{schemaSource: func() resource.Resource { return upgradingResource{} }}
+	if got := r.UpgradeState(context.Background()); len(got) != 1 {
+		t.Errorf("expected the schema source's upgraders, got %v", got)
+	}
+
+	r.schemaSource = func() resource.Resource { return struct{ resource.Resource }{} }
+	if got := r.UpgradeState(context.Background()); got != nil {
+		t.Errorf("expected no upgraders, got %v", got)
+	}
+}
+
+// The registered capabilities must list exactly the attributes of each schema.
+func TestCapabilities_MatchSchemas(t *testing.T) {
+	ctx := context.Background()
+	for _, ver := range versions.Versions() {
+		vp, _ := versions.Lookup(ver)
+		caps := vp.Capabilities()
+		if caps.APIVersion != ver || len(caps.SpecHash) != 64 {
+			t.Errorf("%s: unexpected version %q or spec hash %q", ver, caps.APIVersion, caps.SpecHash)
+		}
+		for typeName, f := range resourceTypes(vp.Resources()) {
+			name := strings.TrimPrefix(typeName, providerTypeName+"_")
+			if !caps.HasResource(name) {
+				t.Errorf("%s: no capabilities for %s", ver, name)
+				continue
+			}
+			attrs := make([]string, 0)
+			for attr := range resourceSchema(ctx, f()).Attributes {
+				attrs = append(attrs, attr)
+			}
+			sort.Strings(attrs)
+			if got := caps.Resources[name].Attributes; !reflect.DeepEqual(got, attrs) {
+				t.Errorf("%s: %s attributes %v, want %v", ver, name, got, attrs)
+			}
+		}
+		if !caps.HasSubEndpoint("node", "/groups") || !caps.HasSubEndpoint("node", "/tags") {
+			t.Errorf("%s: expected the node /groups and /tags sub-endpoints, got %v", ver, caps.Resources["node"].SubEndpoints)
+		}
+	}
+}
+
+func TestVersionedResource_RejectsUnsupportedAttributes(t *testing.T) {
+	schema := resourceschema.Schema{Attributes: map[string]resourceschema.Attribute{
+		"name":     resourceschema.StringAttribute{Optional: true},
+		"settings": resourceschema.StringAttribute{Optional: true},
+	}}
+	typ := schema.Type().TerraformType(context.Background())
+	config := func(settings interface{}) tfsdk.Config {
+		return tfsdk.Config{Schema: schema, Raw: tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, "gw"),
+			"settings": tftypes.NewValue(tftypes.String, settings),
+		})}
+	}
+	r := &versionedResource{
+		typeName: "blastshield_node",
+		capabilities: versions.CapabilitySet{
+			APIVersion: "1.13.0",
+			Resources:  map[string]versions.ResourceCapabilities{"node": {Attributes: []string{"name"}}},
+		},
+	}
+
+	var diags diag.Diagnostics
+	r.rejectUnsupportedAttributes(config(nil), &diags)
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics for an unset attribute: %v", diags)
+	}
+
+	r.rejectUnsupportedAttributes(config("x"), &diags)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", diags)
+	}
+	if want := "Attribute settings of blastshield_node is not supported by Blastshield API version 1.13.0."; diags[0].Detail() != want {
+		t.Errorf("expected %q, got %q", want, diags[0].Detail())
+	}
+}
+
+// nodeSpec returns an API document with a node resource. properties are the
+// properties of Node and NodeCreate.
+func nodeSpec(version, properties string) []byte {
+	return []byte(`{
+  "info": {"version": "` + version + `"},
+  "paths": {
+    "/nodes/": {
+      "get": {
+        "tags": ["Nodes"],
+        "responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}}}}}}
+      },
+      "post": {
+        "tags": ["Nodes"],
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/NodeCreate"}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Node": {"properties": {"id": {"type": "integer"}, ` + properties + `}},
+      "NodeCreate": {"properties": {` + properties + `}, "required": ["name"]}
+    }
+  }
+}`)
+}
+
+// registeredProvider serves the given resource types, for checks made by the
+// framework itself.
+type registeredProvider struct {
+	provider.Provider
+	resources []func() resource.Resource
+}
+
+func (p registeredProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = providerTypeName
+}
+
+func (p registeredProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p registeredProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return p.resources
+}
+
+func (p registeredProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// An attribute the newer API version removed keeps the deprecation message of the
+// older version, so setting it is a warning rather than an unsupported argument.
+func TestVersionedResource_KeepsRemovedAttributes(t *testing.T) {
+	ctx := context.Background()
+	older, err := dynamic.New(nodeSpec("1.13.0", `"name": {"type": "string"}, "master": {"type": "string", "deprecated": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer, err := dynamic.New(nodeSpec("1.14.0", `"name": {"type": "string"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &versionedResource{
+		typeName:     "blastshield_node",
+		schemaSource: resourceTypes(newer.Resources())["blastshield_node"],
+		olderSources: []func() resource.Resource{resourceTypes(older.Resources())["blastshield_node"]},
+	}
+
+	s := r.schema(ctx)
+	master, ok := s.Attributes["master"]
+	if !ok {
+		t.Fatal("expected master to be kept in the schema")
+	}
+	if want := "Deprecated in Blastshield API version 1.13.0."; master.GetDeprecationMessage() != want {
+		t.Errorf("expected deprecation %q, got %q", want, master.GetDeprecationMessage())
+	}
+	if !s.Attributes["name"].IsRequired() {
+		t.Error("expected name to stay required")
+	}
+
+	server := providerserver.NewProtocol6(registeredProvider{
+		resources: []func() resource.Resource{func() resource.Resource { return r }},
+	})()
+	typ := s.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	attrs["name"] = tftypes.NewValue(tftypes.String, "gw")
+	attrs["master"] = tftypes.NewValue(tftypes.String, "gw-1")
+	config, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{TypeName: "blastshield_node", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning || resp.Diagnostics[0].Summary != "Attribute Deprecated" {
+		t.Errorf("expected a deprecation warning, got %+v", resp.Diagnostics)
+	}
+}
+
+// An attribute a newer API version retyped keeps the newer type in the schema,
+// and its values convert to and from the older version's type.
+func TestVersionedResource_RetypedAttribute(t *testing.T) {
+	ctx := context.Background()
+	older, err := dynamic.New(nodeSpec("1.13.0", `"name": {"type": "string"}, "weight": {"type": "integer"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer, err := dynamic.New(nodeSpec("1.14.0", `"name": {"type": "string"}, "weight": {"type": "string"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	olderSource := resourceTypes(older.Resources())["blastshield_node"]
+	r := &versionedResource{
+		typeName:     "blastshield_node",
+		schemaSource: resourceTypes(newer.Resources())["blastshield_node"],
+		olderSources: []func() resource.Resource{olderSource},
+	}
+
+	conv := schemaConverter{
+		outer: r.schema(ctx).Type().TerraformType(ctx),
+		inner: resourceSchema(ctx, olderSource()).Type().TerraformType(ctx),
+	}
+	if got := conv.outer.(tftypes.Object).AttributeTypes["weight"]; !got.Is(tftypes.String) {
+		t.Fatalf("expected weight to keep the newer type, got %s", got)
+	}
+
+	value := func(typ tftypes.Type, weight tftypes.Value) tftypes.Value {
+		attrs := map[string]tftypes.Value{}
+		for name, attrType := range typ.(tftypes.Object).AttributeTypes {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+		attrs["name"] = tftypes.NewValue(tftypes.String, "gw")
+		attrs["weight"] = weight
+		return tftypes.NewValue(typ, attrs)
+	}
+
+	var diags diag.Diagnostics
+	inner := conv.toInner(value(conv.outer, tftypes.NewValue(tftypes.String, "10")), &diags)
+	if want := value(conv.inner, tftypes.NewValue(tftypes.Number, 10)); !inner.Equal(want) {
+		t.Errorf("expected %s, got %s", want, inner)
+	}
+	outer := conv.toOuter(inner, &diags)
+	if want := value(conv.outer, tftypes.NewValue(tftypes.String, "10")); !outer.Equal(want) {
+		t.Errorf("expected %s, got %s", want, outer)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	conv.toInner(value(conv.outer, tftypes.NewValue(tftypes.String, "heavy")), &diags)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "weight") {
+		t.Errorf("expected a conversion error naming weight, got %v", diags)
+	}
+}
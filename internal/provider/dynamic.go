@@ -0,0 +1,147 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/dynamic"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/go-version"
+)
+
+// DynamicSchemaEnv enables the dynamic API version when set to true.
+const DynamicSchemaEnv = "BLASTSHIELD_DYNAMIC_SCHEMA"
+
+// dynamicSchemaTimeout bounds the startup request for the OpenAPI document, which
+// must finish well within the time Terraform gives a plugin to start.
+const dynamicSchemaTimeout = 10 * time.Second
+
+// RegisterDynamicVersion registers an API version built at startup from the
+// orchestrator's OpenAPI document, when BLASTSHIELD_DYNAMIC_SCHEMA is true and the
+// orchestrator is newer than every compiled version. It returns the registered
+// version, or "" when none was registered.
+//
+// Schemas are served before any provider block is configured, so the orchestrator
+// is reached with the connection settings of the environment and credentials file
+// only. The document is fetched in a single short attempt and kept in the version
+// cache; when it cannot be fetched, the compiled versions are served.
+func RegisterDynamicVersion(ctx context.Context, providerVersion string) (string, error) {
+	if enabled, _ := strconv.ParseBool(os.Getenv(DynamicSchemaEnv)); !enabled {
+		return "", nil
+	}
+
+	conn, err := ConnectionFromEnv("")
+	if err != nil {
+		return "", err
+	}
+	if conn.Host == "" {
+		return "", fmt.Errorf("%s needs BLASTSHIELD_HOST or a credentials profile that sets the host", DynamicSchemaEnv)
+	}
+	httpClient, err := conn.Transport.NewHTTPClient(dynamicSchemaTimeout)
+	if err != nil {
+		return "", err
+	}
+	client := NewClient(conn.Host, conn.Token)
+	client.HTTPClient = httpClient
+	client.UserAgent = UserAgent(providerVersion, "")
+	if conn.TokenFile != "" {
+		client.Token = ""
+		client.TokenSource = NewFileTokenSource(conn.TokenFile)
+	}
+
+	spec, err := dynamicSpec(ctx, client, defaultVersionCache())
+	if err != nil || spec == nil {
+		return "", err
+	}
+	return registerDynamicVersion(spec)
+}
+
+// dynamicSpec returns the orchestrator's OpenAPI document, from the cache when it
+// holds one for the host. It returns nil without fetching the document when the
+// cached server version shows that a compiled version is as new.
+func dynamicSpec(ctx context.Context, client *Client, cache *versionCache) ([]byte, error) {
+	if ver, ok := cache.get(client.Host); ok {
+		if !newerThanCompiled(ver) {
+			return nil, nil
+		}
+		if spec, ok := cache.spec(client.Host); ok {
+			return spec, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dynamicSchemaTimeout)
+	defer cancel()
+	spec, err := client.probe(ctx, "/openapi.json")
+	if err != nil {
+		return nil, fmt.Errorf("fetching openapi.json: %w", err)
+	}
+	var doc struct {
+		Info struct {
+			Version string `json:"version"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("parsing openapi.json: %w", err)
+	}
+	if doc.Info.Version == "" {
+		return nil, fmt.Errorf("openapi.json missing info.version")
+	}
+
+	// A cache that cannot be written only costs the next start another download
+	if !newerThanCompiled(doc.Info.Version) {
+		_ = cache.put(client.Host, doc.Info.Version)
+		return nil, nil
+	}
+	_ = cache.putSpec(client.Host, doc.Info.Version, spec)
+	return spec, nil
+}
+
+// newerThanCompiled reports whether ver is newer than every compiled version.
+func newerThanCompiled(ver string) bool {
+	v, err := version.NewVersion(ver)
+	if err != nil {
+		return false
+	}
+	_, latest := versions.LatestVersion()
+	lv, err := version.NewVersion(latest)
+	return err != nil || v.GreaterThan(lv)
+}
+
+// registerDynamicVersion registers the version described by spec unless a
+// compiled version is as new.
+func registerDynamicVersion(spec []byte) (string, error) {
+	vp, err := dynamic.New(spec)
+	if err != nil {
+		return "", err
+	}
+	ver, err := version.NewVersion(vp.Version())
+	if err != nil {
+		return "", fmt.Errorf("invalid API version %q: %w", vp.Version(), err)
+	}
+	if compiled, latest := versions.LatestVersion(); compiled != nil {
+		if lv, err := version.NewVersion(latest); err == nil && !ver.GreaterThan(lv) {
+			return "", nil
+		}
+		vp.InheritSchemaChanges(compiled.Resources())
+	}
+	versions.Register(vp.Version(), vp)
+	return vp.Version(), nil
+}
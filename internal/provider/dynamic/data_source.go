@@ -0,0 +1,199 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/apierror"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Single entity data source
+
+var _ datasource.DataSource = &DataSource{}
+
+// DataSource fetches an entity of a resource found in the OpenAPI document by ID.
+type DataSource struct {
+	info   *resourceInfo
+	client Client
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.info.tfName
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dataSourceSchema(d.info)
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, response *datasource.ReadResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.Config.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+	id, err := d.info.idValue(attrs["id"])
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Unable to read the %s ID: %s", d.info.tfName, err))
+		return
+	}
+
+	var ent entity
+	if err := d.client.Read(ctx, d.info.entityPath(id), &ent); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read "+d.info.tfName, err)
+		return
+	}
+	if err := d.info.setDataSourceEntity(attrs, ent, d.client.TagConfig()); err != nil {
+		response.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse %s: %s", d.info.tfName, err))
+		return
+	}
+
+	if d.info.hasGroups {
+		// Fetch groups for this resource
+		var groups []groupMembership
+		if err := d.client.GetGroupsRaw(ctx, d.info.path, id, &groups); err == nil {
+			attrs["groups"] = groupsToTerraform(groups)
+		} else {
+			attrs["groups"] = groupsToTerraform(nil)
+		}
+	}
+
+	response.State.Raw = tftypes.NewValue(req.Config.Raw.Type(), attrs)
+}
+
+// List data source
+
+var _ datasource.DataSource = &ListDataSource{}
+
+// ListDataSource lists the entities of a resource found in the OpenAPI document,
+// filtered by the query parameters of its list endpoint.
+type ListDataSource struct {
+	info   *resourceInfo
+	client Client
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.info.tfPluralName
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = listDataSourceSchema(d.info)
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureClient(req, resp)
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, response *datasource.ReadResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.Config.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid Configuration", err.Error())
+		return
+	}
+
+	params := url.Values{}
+	for _, qp := range d.info.queryParams {
+		v := attrs[qp.tfName]
+		if !known(v) {
+			continue
+		}
+		values := []tftypes.Value{v}
+		if qp.isList {
+			if err := v.As(&values); err != nil {
+				response.Diagnostics.AddError("Invalid Configuration", err.Error())
+				return
+			}
+		}
+		for _, value := range values {
+			param, err := scalarToAPI(qp.tfType, value)
+			if err != nil {
+				response.Diagnostics.AddError("Invalid Configuration", err.Error())
+				return
+			}
+			params.Add(qp.name, fmt.Sprint(param))
+		}
+	}
+
+	var results []entity
+	if err := d.client.ListWithMultiParams(ctx, d.info.path, params, &results); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to list "+d.info.tfPluralName, err)
+		return
+	}
+
+	listType := req.Config.Raw.Type().(tftypes.Object).AttributeTypes[d.info.tfPluralName].(tftypes.List)
+	items := make([]tftypes.Value, len(results))
+	for i, ent := range results {
+		item := map[string]tftypes.Value{}
+		if err := d.info.setDataSourceEntity(item, ent, d.client.TagConfig()); err != nil {
+			response.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse %s: %s", d.info.tfName, err))
+			return
+		}
+		if d.info.hasGroups {
+			// groups are not fetched in list data source
+			item["groups"] = groupsToTerraform(nil)
+		}
+		items[i] = tftypes.NewValue(listType.ElementType, item)
+	}
+	attrs[d.info.tfPluralName] = tftypes.NewValue(listType, items)
+
+	response.State.Raw = tftypes.NewValue(req.Config.Raw.Type(), attrs)
+}
+
+func configureClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) Client {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected Client, got: %T", req.ProviderData),
+		)
+		return nil
+	}
+	return client
+}
+
+// setDataSourceEntity maps an API response onto the attributes of a data source.
+// Data sources report every tag but ignored ones; default_tags only apply to
+// resources.
+func (r *resourceInfo) setDataSourceEntity(attrs map[string]tftypes.Value, ent entity, tagConfig *tagging.Config) error {
+	if err := r.setEntity(attrs, ent); err != nil {
+		return err
+	}
+	if r.hasTags {
+		apiTags, err := entityTags(ent)
+		if err != nil {
+			return err
+		}
+		attrs["tags"] = stringMapValue(tagConfig.Visible(apiTags))
+		attrs["tags_all"] = attrs["tags"]
+	}
+	if r.storePostResponse {
+		// invitation is not available from data source (only returned on resource creation)
+		attrs["invitation"] = tftypes.NewValue(tftypes.String, nil)
+	}
+	return nil
+}
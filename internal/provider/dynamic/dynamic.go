@@ -0,0 +1,196 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic builds a versioned provider at runtime from an orchestrator's
+// OpenAPI document. It applies the same mapping rules as generate.py, so a server
+// newer than every compiled version package can be used before a provider release
+// supports it.
+package dynamic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/stateupgrade"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Client defines the API client used by the dynamic resources. It is the same
+// interface the generated packages use, and provider.Client satisfies it.
+type Client interface {
+	CreateRaw(ctx context.Context, path string, body interface{}) ([]byte, error)
+	Read(ctx context.Context, path string, result interface{}) error
+	Update(ctx context.Context, path string, body interface{}, result interface{}) error
+	Delete(ctx context.Context, path string) error
+	ListWithMultiParams(ctx context.Context, path string, params url.Values, result interface{}) error
+	GetGroupsRaw(ctx context.Context, basePath string, id interface{}, result interface{}) error
+	UpdateGroupsRaw(ctx context.Context, basePath string, id interface{}, groups interface{}, result interface{}) error
+	TagConfig() *tagging.Config
+	IsReadOnly() bool
+}
+
+// Provider serves the resources and data sources described by an OpenAPI document.
+// It implements versions.VersionedProvider.
+type Provider struct {
+	version   string
+	specHash  string
+	resources []*resourceInfo
+}
+
+// New parses an OpenAPI document and returns a provider for its API version.
+func New(spec []byte) (*Provider, error) {
+	var doc openAPISpec
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+	if doc.Info.Version == "" {
+		return nil, fmt.Errorf("OpenAPI document missing info.version")
+	}
+	resources, err := findResources(&doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("OpenAPI document %s defines no resources", doc.Info.Version)
+	}
+	hash := sha256.Sum256(spec)
+	return &Provider{version: doc.Info.Version, specHash: hex.EncodeToString(hash[:]), resources: resources}, nil
+}
+
+// Version returns the API version of the OpenAPI document.
+func (p *Provider) Version() string {
+	return p.version
+}
+
+// InheritSchemaChanges continues the schema versions of the resources of a compiled
+// version, so state written by it is upgraded rather than rejected. The document
+// does not say what changed since, so only the compiled version's changes apply.
+func (p *Provider) InheritSchemaChanges(resources []func() resource.Resource) {
+	changes := map[string][]stateupgrade.Change{}
+	for _, factory := range resources {
+		r := factory()
+		v, ok := r.(stateupgrade.Versioned)
+		if !ok {
+			continue
+		}
+		var meta resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{}, &meta)
+		changes[meta.TypeName] = v.SchemaChanges()
+	}
+	for _, info := range p.resources {
+		info.schemaChanges = changes["_"+info.tfName]
+	}
+}
+
+func (p *Provider) Resources() []func() resource.Resource {
+	factories := make([]func() resource.Resource, 0, len(p.resources))
+	for _, info := range p.resources {
+		factories = append(factories, func() resource.Resource {
+			return &Resource{info: info}
+		})
+	}
+	return factories
+}
+
+func (p *Provider) DataSources() []func() datasource.DataSource {
+	factories := make([]func() datasource.DataSource, 0, 2*len(p.resources))
+	for _, info := range p.resources {
+		factories = append(factories,
+			func() datasource.DataSource { return &DataSource{info: info} },
+			func() datasource.DataSource { return &ListDataSource{info: info} },
+		)
+	}
+	return factories
+}
+
+func (p *Provider) Capabilities() versions.CapabilitySet {
+	caps := versions.CapabilitySet{
+		APIVersion: p.version,
+		SpecHash:   p.specHash,
+		Resources:  make(map[string]versions.ResourceCapabilities, len(p.resources)),
+	}
+	for _, info := range p.resources {
+		caps.Resources[info.tfName] = versions.ResourceCapabilities{
+			Attributes:   info.attributes(),
+			SubEndpoints: info.subEndpoints,
+		}
+	}
+	return caps
+}
+
+// attributes returns the sorted attribute names of the resource schema.
+func (r *resourceInfo) attributes() []string {
+	var names []string
+	for _, f := range r.fields {
+		if f.inSchema() {
+			names = append(names, f.tfName)
+		}
+	}
+	if r.storePostResponse {
+		names = append(names, "invitation")
+	}
+	if r.hasGroups {
+		names = append(names, "groups")
+	}
+	if r.hasTags {
+		names = append(names, "tags_all")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// entityPath returns the path of the entity with the given ID.
+func (r *resourceInfo) entityPath(id interface{}) string {
+	return fmt.Sprintf("%s%v", r.path, id)
+}
+
+// idValue returns the ID held by the id attribute, a string or an int64.
+func (r *resourceInfo) idValue(v tftypes.Value) (interface{}, error) {
+	if r.idType == "string" {
+		var id string
+		err := v.As(&id)
+		return id, err
+	}
+	var f big.Float
+	if err := v.As(&f); err != nil {
+		return nil, err
+	}
+	id, _ := f.Int64()
+	return id, nil
+}
+
+// setEntity sets the attributes of every schema field from an API response.
+func (r *resourceInfo) setEntity(attrs map[string]tftypes.Value, ent entity) error {
+	for _, f := range r.fields {
+		if !f.inSchema() {
+			continue
+		}
+		v, err := fromAPI(f, ent[f.jsonName])
+		if err != nil {
+			return err
+		}
+		attrs[f.tfName] = v
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package dynamic
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testSpec = `{
+  "info": {"version": "2.0.0"},
+  "paths": {
+    "/endpoints/": {
+      "get": {
+        "tags": ["Endpoints"],
+        "parameters": [
+          {"name": "name", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "tags.key", "in": "query", "schema": {"type": "string"}},
+          {"name": "enabled", "in": "query", "schema": {"type": "boolean"}}
+        ],
+        "responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Endpoint"}}}}}}
+      },
+      "post": {
+        "tags": ["Endpoints"],
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/EndpointCreate"}}}}
+      }
+    },
+    "/endpoints/bulk": {
+      "post": {
+        "tags": ["Endpoints"],
+        "requestBody": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/EndpointCreate"}}}}}
+      }
+    },
+    "/egress_policies/": {
+      "get": {
+        "tags": ["Egress Policies"],
+        "deprecated": true,
+        "responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/EgressPolicy"}}}}}}
+      }
+    },
+    "/audit/": {
+      "get": {
+        "tags": ["Audit"],
+        "responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Endpoint"}}}}}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Endpoint": {
+        "properties": {
+          "id": {"type": "integer"},
+          "name": {"type": "string"},
+          "address": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+          "tags": {"type": "object", "additionalProperties": {"type": "string"}},
+          "dns_name": {"type": "array", "items": {"type": "string"}, "deprecated": true},
+          "mode": {"$ref": "#/components/schemas/Mode"},
+          "status": {"$ref": "#/components/schemas/EndpointStatus"}
+        },
+        "required": ["id", "name"]
+      },
+      "EndpointCreate": {
+        "properties": {
+          "name": {"type": "string"},
+          "address": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+          "tags": {"type": "object", "additionalProperties": {"type": "string"}},
+          "dns_name": {"type": "array", "items": {"type": "string"}}
+        },
+        "required": ["name", "address"]
+      },
+      "EndpointStatus": {
+        "properties": {"reachable": {"type": "boolean"}, "mac_address": {"type": "string"}},
+        "required": ["reachable", "mac_address"]
+      },
+      "Mode": {"type": "string", "enum": ["a", "b"]},
+      "EgressPolicy": {
+        "properties": {
+          "id": {"type": "integer"},
+          "dns_names": {"type": "array", "items": {"$ref": "#/components/schemas/DNSName"}},
+          "settings": {"anyOf": [{"$ref": "#/components/schemas/PolicySettings"}, {"type": "null"}]}
+        }
+      },
+      "PolicySettings": {
+        "properties": {
+          "enabled": {"type": "boolean"},
+          "comment": {"anyOf": [{"type": "string"}, {"type": "null"}]},
+          "dns_names": {"type": "array", "items": {"$ref": "#/components/schemas/DNSName"}}
+        }
+      },
+      "DNSName": {
+        "properties": {"name": {"type": "string"}, "recursive": {"type": "boolean"}},
+        "required": ["name"]
+      }
+    }
+  }
+}`
+
+func testProvider(t *testing.T) *Provider {
+	t.Helper()
+	p, err := New([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNew_FindsResources(t *testing.T) {
+	p := testProvider(t)
+	if p.Version() != "2.0.0" {
+		t.Errorf("expected version 2.0.0, got %s", p.Version())
+	}
+	if len(p.resources) != 2 {
+		t.Fatalf("expected 2 resources, got %d", len(p.resources))
+	}
+
+	endpoint, policy := p.resources[0], p.resources[1]
+	if endpoint.tfName != "endpoint" || endpoint.path != "/endpoints/" || endpoint.idType != "int64" {
+		t.Errorf("unexpected endpoint resource: %+v", endpoint)
+	}
+	if policy.tfName != "egress_policy" || policy.tfPluralName != "egress_policies" {
+		t.Errorf("unexpected egress policy names: %s, %s", policy.tfName, policy.tfPluralName)
+	}
+
+	var params []string
+	for _, qp := range endpoint.queryParams {
+		params = append(params, qp.tfName)
+	}
+	if !reflect.DeepEqual(params, []string{"name", "enabled"}) {
+		t.Errorf("expected query params [name enabled], got %v", params)
+	}
+
+	if f := endpoint.field("status"); f == nil || !f.readOnlyObject() || len(f.nestedFields) != 2 {
+		t.Errorf("expected status to be a read-only object, got %+v", f)
+	}
+	if f := endpoint.field("mode"); f == nil || f.tfType != "String" || !f.computed || f.optional {
+		t.Errorf("expected mode to be a computed string, got %+v", f)
+	}
+	if f := endpoint.field("name"); !f.required {
+		t.Error("expected name to be required")
+	}
+	if f := endpoint.field("address"); f.required || !f.optional || !f.computed || !f.isPointer {
+		t.Errorf("expected nullable-required address to be optional and computed, got %+v", f)
+	}
+	if !endpoint.hasTags || !endpoint.hasGroups {
+		t.Error("expected endpoint to have tags and groups")
+	}
+
+	dnsNames := policy.field("dns_names")
+	if !dnsNames.inSchema() || len(dnsNames.nestedFields) != 2 || !dnsNames.nestedFields[0].required {
+		t.Errorf("unexpected nested field: %+v", dnsNames)
+	}
+}
+
+func TestNew_Deprecations(t *testing.T) {
+	p := testProvider(t)
+	endpoint, policy := p.resources[0], p.resources[1]
+	const want = "Deprecated in Blastshield API version 2.0.0."
+
+	s := resourceSchema(endpoint)
+	if s.DeprecationMessage != "" || s.Attributes["name"].GetDeprecationMessage() != "" {
+		t.Errorf("did not expect endpoint or its name to be deprecated")
+	}
+	if got := s.Attributes["dns_name"].GetDeprecationMessage(); got != want {
+		t.Errorf("expected dns_name deprecation %q, got %q", want, got)
+	}
+
+	if got := resourceSchema(policy).DeprecationMessage; got != want {
+		t.Errorf("expected resource deprecation %q, got %q", want, got)
+	}
+	if got := dataSourceSchema(policy).DeprecationMessage; got != want {
+		t.Errorf("expected data source deprecation %q, got %q", want, got)
+	}
+	if got := listDataSourceSchema(policy).DeprecationMessage; got != want {
+		t.Errorf("expected list data source deprecation %q, got %q", want, got)
+	}
+}
+
+func TestFromAPI(t *testing.T) {
+	p := testProvider(t)
+	endpoint := p.resources[0]
+
+	attrs := map[string]tftypes.Value{}
+	if err := endpoint.setEntity(attrs, entity{
+		"id":   json.RawMessage(`7`),
+		"name": json.RawMessage(`"web"`),
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !attrs["address"].IsNull() {
+		t.Errorf("expected a missing nullable field to be null, got %s", attrs["address"])
+	}
+	if !attrs["mode"].Equal(tftypes.NewValue(tftypes.String, "")) {
+		t.Errorf("expected a missing field to be empty, got %s", attrs["mode"])
+	}
+	if want := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{}); !attrs["dns_name"].Equal(want) {
+		t.Errorf("expected a missing list to be an empty set, got %s", attrs["dns_name"])
+	}
+	if want := tftypes.NewValue(stringMapType, map[string]tftypes.Value{}); !attrs["tags"].Equal(want) {
+		t.Errorf("expected missing tags to be an empty map, got %s", attrs["tags"])
+	}
+}
+
+func TestObjectField(t *testing.T) {
+	p := testProvider(t)
+	settings := p.resources[1].field("settings")
+	if !settings.inSchema() || settings.isList || len(settings.nestedFields) != 3 {
+		t.Fatalf("unexpected single nested field: %+v", settings)
+	}
+
+	if v, err := fromAPI(settings, nil); err != nil || !v.IsNull() {
+		t.Errorf("expected a missing nullable object to be null, got %s (%v)", v, err)
+	}
+	v, err := fromAPI(settings, json.RawMessage(`{"enabled": true, "dns_names": [{"name": "example.com"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["enabled"].Equal(tftypes.NewValue(tftypes.Bool, true)) || !attrs["comment"].IsNull() {
+		t.Errorf("unexpected object attributes: %s", v)
+	}
+
+	attrs["enabled"] = tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)
+	body, err := toAPI(settings, tftypes.NewValue(v.Type(), attrs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"dns_names": []map[string]interface{}{{"name": "example.com", "recursive": false}},
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("expected only known attributes to be sent, got %#v", body)
+	}
+}
+
+type fakeClient struct {
+	entities   map[string]string
+	created    map[string]interface{}
+	tagConfig  *tagging.Config
+	groupsRead int
+}
+
+func (c *fakeClient) CreateRaw(ctx context.Context, path string, body interface{}) ([]byte, error) {
+	c.created = body.(map[string]interface{})
+	return []byte(`{"id": 7}`), nil
+}
+
+func (c *fakeClient) Read(ctx context.Context, path string, result interface{}) error {
+	return json.Unmarshal([]byte(c.entities[path]), result)
+}
+
+func (c *fakeClient) Update(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.Read(ctx, path, result)
+}
+
+func (c *fakeClient) Delete(ctx context.Context, path string) error {
+	return nil
+}
+
+func (c *fakeClient) ListWithMultiParams(ctx context.Context, path string, params url.Values, result interface{}) error {
+	return nil
+}
+
+func (c *fakeClient) GetGroupsRaw(ctx context.Context, basePath string, id interface{}, result interface{}) error {
+	c.groupsRead++
+	return json.Unmarshal([]byte(`[{"id": 3, "expires": 0}]`), result)
+}
+
+func (c *fakeClient) UpdateGroupsRaw(ctx context.Context, basePath string, id interface{}, groups interface{}, result interface{}) error {
+	return nil
+}
+
+func (c *fakeClient) TagConfig() *tagging.Config {
+	return c.tagConfig
+}
+
+func (c *fakeClient) IsReadOnly() bool {
+	return false
+}
+
+func TestResource_Create(t *testing.T) {
+	ctx := context.Background()
+	p := testProvider(t)
+	client := &fakeClient{
+		entities: map[string]string{
+			"/endpoints/7": `{"id": 7, "name": "web", "address": "10.0.0.7", "tags": {"env": "prod", "team": "net"}}`,
+		},
+		tagConfig: &tagging.Config{DefaultTags: map[string]string{"team": "net"}},
+	}
+	r := &Resource{info: p.resources[0], client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+	attrs["name"] = tftypes.NewValue(tftypes.String, "web")
+	attrs["tags"] = tftypes.NewValue(stringMapType, map[string]tftypes.Value{"env": tftypes.NewValue(tftypes.String, "prod")})
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	want := map[string]interface{}{
+		"name":    "web",
+		"address": nil,
+		"tags":    map[string]string{"env": "prod", "team": "net"},
+	}
+	if !reflect.DeepEqual(client.created, want) {
+		t.Errorf("unexpected create request: %#v", client.created)
+	}
+
+	var state map[string]tftypes.Value
+	if err := resp.State.Raw.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	if !state["address"].Equal(tftypes.NewValue(tftypes.String, "10.0.0.7")) {
+		t.Errorf("unexpected address: %s", state["address"])
+	}
+	if want := stringMapValue(map[string]string{"env": "prod"}); !state["tags"].Equal(want) {
+		t.Errorf("expected the default tag to be left out of tags, got %s", state["tags"])
+	}
+	if want := stringMapValue(map[string]string{"env": "prod", "team": "net"}); !state["tags_all"].Equal(want) {
+		t.Errorf("unexpected tags_all: %s", state["tags_all"])
+	}
+	if want := groupsToTerraform([]groupMembership{{ID: 3}}); !state["groups"].Equal(want) {
+		t.Errorf("unexpected groups: %s", state["groups"])
+	}
+	if !resp.State.Raw.IsFullyKnown() {
+		t.Errorf("expected a fully known state, got %s", resp.State.Raw)
+	}
+}
+
+func TestResource_UpdateKeepsStatus(t *testing.T) {
+	ctx := context.Background()
+	p := testProvider(t)
+	client := &fakeClient{
+		entities: map[string]string{
+			"/endpoints/7": `{"id": 7, "name": "db", "address": "10.0.0.7", "status": {"reachable": true, "mac_address": "aa:bb"}}`,
+		},
+		tagConfig: &tagging.Config{},
+	}
+	r := &Resource{info: p.resources[0], client: client}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	status, ok := schemaResp.Schema.Attributes["status"].(resourceschema.SingleNestedAttribute)
+	if !ok || !status.Computed || status.Optional || len(status.PlanModifiers) != 1 {
+		t.Fatalf("expected status to be computed and kept from state in plans, got %#v", schemaResp.Schema.Attributes["status"])
+	}
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	statusType := objType.AttributeTypes["status"]
+
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	attrs["id"] = tftypes.NewValue(tftypes.Number, 7)
+	attrs["name"] = tftypes.NewValue(tftypes.String, "web")
+	attrs["status"] = tftypes.NewValue(statusType, map[string]tftypes.Value{
+		"reachable":   tftypes.NewValue(tftypes.Bool, false),
+		"mac_address": tftypes.NewValue(tftypes.String, "N/A"),
+	})
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}
+	attrs["name"] = tftypes.NewValue(tftypes.String, "db")
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}
+
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var got map[string]tftypes.Value
+	if err := resp.State.Raw.As(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !got["name"].Equal(tftypes.NewValue(tftypes.String, "db")) {
+		t.Errorf("unexpected name: %s", got["name"])
+	}
+	if !got["status"].Equal(attrs["status"]) {
+		t.Errorf("expected the planned status to be kept, got %s", got["status"])
+	}
+}
+
+func TestDataSource_IgnoresTags(t *testing.T) {
+	ctx := context.Background()
+	p := testProvider(t)
+	client := &fakeClient{
+		entities: map[string]string{
+			"/endpoints/7": `{"id": 7, "name": "web", "tags": {"env": "prod", "team": "net", "scanner": "ok"}}`,
+		},
+		tagConfig: &tagging.Config{DefaultTags: map[string]string{"team": "net"}, IgnoreKeys: []string{"scanner"}},
+	}
+	d := &DataSource{info: p.resources[0], client: client}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attrs := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+	attrs["id"] = tftypes.NewValue(objType.AttributeTypes["id"], 7)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, attrs)}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state map[string]tftypes.Value
+	if err := resp.State.Raw.As(&state); err != nil {
+		t.Fatal(err)
+	}
+	want := stringMapValue(map[string]string{"env": "prod", "team": "net"})
+	if !state["tags"].Equal(want) || !state["tags_all"].Equal(want) {
+		t.Errorf("expected every tag but the ignored one, got %s and %s", state["tags"], state["tags_all"])
+	}
+}
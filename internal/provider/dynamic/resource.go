@@ -0,0 +1,438 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/apierror"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/planning"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/stateupgrade"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+var _ resource.ResourceWithUpgradeState = &Resource{}
+
+// Resource manages an entity of a resource found in the OpenAPI document. It
+// behaves like the generated resource for the same spec.
+type Resource struct {
+	info   *resourceInfo
+	client Client
+}
+
+func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.info.tfName
+}
+
+func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = resourceSchema(r.info)
+}
+
+// UpgradeState upgrades state written with an earlier schema version of the
+// compiled version the schema changes were inherited from.
+func (r *Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateupgrade.Upgraders(ctx, resourceSchema(r.info), r.info.schemaChanges)
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan rejects changes when the provider is read-only and plans tags_all
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	if r.info.hasTags && !req.Plan.Raw.IsNull() {
+		planning.PlanTagsAll(ctx, r.client.TagConfig(), &response.Plan, &response.Diagnostics)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if r.client.IsReadOnly() {
+		planning.RejectReadOnlyChange(req.State, response.Plan, "blastshield_"+r.info.tfName, &response.Diagnostics)
+	}
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, response *resource.CreateResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.Plan.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid Plan", err.Error())
+		return
+	}
+
+	createReq, err := r.createBody(attrs)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Plan", fmt.Sprintf("Unable to build the %s create request: %s", r.info.tfName, err))
+		return
+	}
+
+	postResp, err := r.client.CreateRaw(ctx, r.info.path, createReq)
+	if err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to create "+r.info.tfName, err)
+		return
+	}
+
+	if r.info.storePostResponse {
+		// Store POST response as base64
+		attrs["invitation"] = tftypes.NewValue(tftypes.String, base64.StdEncoding.EncodeToString(postResp))
+	}
+
+	// Extract ID from POST response
+	var postResult map[string]interface{}
+	if err := json.Unmarshal(postResp, &postResult); err != nil {
+		response.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse create response: %s", err))
+		return
+	}
+	var id interface{}
+	switch v := postResult[r.info.postIDField].(type) {
+	case string:
+		if r.info.idType == "string" {
+			id = v
+		}
+	case float64:
+		if r.info.idType == "int64" {
+			id = int64(v)
+		}
+	}
+	if id == nil {
+		response.Diagnostics.AddError("Client Error", "POST response missing "+r.info.postIDField+" field")
+		return
+	}
+
+	// GET the full entity
+	var ent entity
+	if err := r.client.Read(ctx, r.info.entityPath(id), &ent); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read created "+r.info.tfName, err)
+		return
+	}
+
+	if !r.setState(ctx, attrs, ent, attrs["tags"], &response.Diagnostics) {
+		return
+	}
+	if r.info.hasGroups && !r.syncGroups(ctx, id, attrs, &response.Diagnostics) {
+		return
+	}
+
+	response.State.Raw = tftypes.NewValue(req.Plan.Raw.Type(), attrs)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, response *resource.ReadResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.State.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid State", err.Error())
+		return
+	}
+	id, err := r.info.idValue(attrs["id"])
+	if err != nil {
+		response.Diagnostics.AddError("Invalid State", fmt.Sprintf("Unable to read the %s ID: %s", r.info.tfName, err))
+		return
+	}
+
+	// invitation is not returned by GET and is kept from state
+	var ent entity
+	if err := r.client.Read(ctx, r.info.entityPath(id), &ent); err != nil {
+		if errors.Is(err, apierror.ErrNotFound) {
+			// Deleted outside of Terraform - drop it from state so it is planned for re-creation
+			response.State.RemoveResource(ctx)
+			return
+		}
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read "+r.info.tfName, err)
+		return
+	}
+
+	if !r.setState(ctx, attrs, ent, attrs["tags"], &response.Diagnostics) {
+		return
+	}
+
+	if r.info.hasGroups {
+		var groups []groupMembership
+		if err := r.client.GetGroupsRaw(ctx, r.info.path, id, &groups); err != nil {
+			if errors.Is(err, apierror.ErrNotFound) {
+				// Deleted between the two requests
+				response.State.RemoveResource(ctx)
+				return
+			}
+			apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read "+r.info.tfName+" groups", err)
+			return
+		}
+		attrs["groups"] = groupsToTerraform(groups)
+	}
+
+	response.State.Raw = tftypes.NewValue(req.State.Raw.Type(), attrs)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, response *resource.UpdateResponse) {
+	var attrs, stateAttrs map[string]tftypes.Value
+	if err := req.Plan.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid Plan", err.Error())
+		return
+	}
+	if err := req.State.Raw.As(&stateAttrs); err != nil {
+		response.Diagnostics.AddError("Invalid State", err.Error())
+		return
+	}
+
+	updateReq, err := r.updateBody(attrs)
+	if err != nil {
+		response.Diagnostics.AddError("Invalid Plan", fmt.Sprintf("Unable to build the %s update request: %s", r.info.tfName, err))
+		return
+	}
+
+	// Get the ID from state (computed fields aren't in the plan)
+	id, err := r.info.idValue(stateAttrs["id"])
+	if err != nil {
+		response.Diagnostics.AddError("Invalid State", fmt.Sprintf("Unable to read the %s ID: %s", r.info.tfName, err))
+		return
+	}
+	entityPath := r.info.entityPath(id)
+
+	if r.info.hasTags {
+		// The API replaces all tags, so ignored tags set outside of Terraform are read
+		// first and sent back unchanged
+		tagConfig := r.client.TagConfig()
+		var currentTags map[string]string
+		if tagConfig.HasIgnores() {
+			var current struct {
+				Tags map[string]string `json:"tags"`
+			}
+			if err := r.client.Read(ctx, entityPath, &current); err != nil {
+				apierror.AddError(&response.Diagnostics, "Client Error", "Unable to read "+r.info.tfName+" tags", err)
+				return
+			}
+			currentTags = current.Tags
+		}
+		ownTags, err := stringMap(attrs["tags"])
+		if err != nil {
+			response.Diagnostics.AddError("Invalid Plan", err.Error())
+			return
+		}
+		priorTags, err := stringMap(stateAttrs["tags_all"])
+		if err != nil {
+			response.Diagnostics.AddError("Invalid State", err.Error())
+			return
+		}
+		if tags := tagConfig.Merge(ownTags, priorTags, currentTags); tags != nil {
+			updateReq["tags"] = tags
+		}
+	}
+
+	var ent entity
+	if err := r.client.Update(ctx, entityPath, updateReq, &ent); err != nil {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to update "+r.info.tfName, err)
+		return
+	}
+
+	if !r.setState(ctx, attrs, ent, attrs["tags"], &response.Diagnostics) {
+		return
+	}
+	if r.info.storePostResponse {
+		// Restore invitation from state
+		attrs["invitation"] = stateAttrs["invitation"]
+	}
+	for _, f := range r.info.fields {
+		if f.readOnlyObject() && f.inSchema() {
+			// Keep read-only objects as planned; they are refreshed by the next read
+			attrs[f.tfName] = stateAttrs[f.tfName]
+		}
+	}
+	if r.info.hasGroups && !r.syncGroups(ctx, id, attrs, &response.Diagnostics) {
+		return
+	}
+
+	response.State.Raw = tftypes.NewValue(req.Plan.Raw.Type(), attrs)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, response *resource.DeleteResponse) {
+	var attrs map[string]tftypes.Value
+	if err := req.State.Raw.As(&attrs); err != nil {
+		response.Diagnostics.AddError("Invalid State", err.Error())
+		return
+	}
+	id, err := r.info.idValue(attrs["id"])
+	if err != nil {
+		response.Diagnostics.AddError("Invalid State", fmt.Sprintf("Unable to read the %s ID: %s", r.info.tfName, err))
+		return
+	}
+
+	err = r.client.Delete(ctx, r.info.entityPath(id))
+	// Already gone is the desired end state
+	if err != nil && !errors.Is(err, apierror.ErrNotFound) {
+		apierror.AddError(&response.Diagnostics, "Client Error", "Unable to delete "+r.info.tfName, err)
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.info.idType == "string" {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse ID as integer: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// setState maps an API response onto attrs. configuredTags holds the resource's
+// tags from the plan or prior state, captured before the mapping.
+func (r *Resource) setState(ctx context.Context, attrs map[string]tftypes.Value, ent entity, configuredTags tftypes.Value, diags *diag.Diagnostics) bool {
+	configured, err := stringMap(configuredTags)
+	if err != nil {
+		diags.AddError("Invalid Plan", err.Error())
+		return false
+	}
+	if err := r.info.setEntity(attrs, ent); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse %s: %s", r.info.tfName, err))
+		return false
+	}
+	if r.info.hasTags {
+		apiTags, err := entityTags(ent)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to parse %s tags: %s", r.info.tfName, err))
+			return false
+		}
+		own, all := r.client.TagConfig().Split(apiTags, configured)
+		attrs["tags"] = stringMapValue(own)
+		attrs["tags_all"] = stringMapValue(all)
+	}
+	return true
+}
+
+// syncGroups applies the planned group memberships, unless groups is unknown, and
+// reads them back.
+func (r *Resource) syncGroups(ctx context.Context, id interface{}, attrs map[string]tftypes.Value, diags *diag.Diagnostics) bool {
+	plannedGroups, err := groupsFromTerraform(attrs["groups"])
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse groups: %s", err))
+		return false
+	}
+	if plannedGroups != nil {
+		var updatedGroups []groupMembership
+		if err := r.client.UpdateGroupsRaw(ctx, r.info.path, id, plannedGroups, &updatedGroups); err != nil {
+			apierror.AddError(diags, "Client Error", "Unable to update "+r.info.tfName+" groups", err)
+			return false
+		}
+	}
+
+	// Fetch groups from API
+	var groups []groupMembership
+	if err := r.client.GetGroupsRaw(ctx, r.info.path, id, &groups); err != nil {
+		apierror.AddError(diags, "Client Error", "Unable to read "+r.info.tfName+" groups", err)
+		return false
+	}
+	attrs["groups"] = groupsToTerraform(groups)
+	return true
+}
+
+// createBody builds the create request from the planned attributes. Required
+// fields are always sent, optional fields only when they are known.
+func (r *Resource) createBody(attrs map[string]tftypes.Value) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	for _, cf := range r.info.createFields {
+		f := r.info.field(cf.jsonName)
+		if f == nil || !f.inSchema() {
+			continue
+		}
+		v := attrs[f.tfName]
+		switch {
+		case r.info.nullableRequired[cf.jsonName]:
+			// nullable-required: null is sent if not set (API auto-assigns)
+			body[cf.jsonName] = nil
+			if known(v) {
+				value, err := toAPI(f, v)
+				if err != nil {
+					return nil, err
+				}
+				body[cf.jsonName] = value
+			}
+		case r.info.hasTags && cf.jsonName == "tags":
+			// Default tags from the provider configuration are merged in
+			ownTags, err := stringMap(v)
+			if err != nil {
+				return nil, err
+			}
+			if tags := r.client.TagConfig().Merge(ownTags, nil, nil); len(tags) > 0 {
+				body["tags"] = tags
+			}
+		case cf.required && !f.isList && !f.isMap && !f.isNested:
+			value, err := scalarToAPI(f.tfType, v)
+			if err != nil {
+				return nil, err
+			}
+			body[cf.jsonName] = value
+		case known(v):
+			value, err := toAPI(f, v)
+			if err != nil {
+				return nil, err
+			}
+			body[cf.jsonName] = value
+		case cf.required:
+			// Required list field - always send
+			body[cf.jsonName] = []interface{}{}
+		}
+	}
+	return body, nil
+}
+
+// updateBody builds the update request from the known planned attributes. tags
+// are added by Update once the current tags are known.
+func (r *Resource) updateBody(attrs map[string]tftypes.Value) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	for _, cf := range r.info.createFields {
+		f := r.info.field(cf.jsonName)
+		if f == nil || !f.inSchema() || (r.info.hasTags && cf.jsonName == "tags") {
+			continue
+		}
+		v := attrs[f.tfName]
+		if !known(v) {
+			continue
+		}
+		value, err := toAPI(f, v)
+		if err != nil {
+			return nil, err
+		}
+		body[cf.jsonName] = value
+	}
+	return body, nil
+}
@@ -0,0 +1,366 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/stateupgrade"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The schemas below are built to be identical to the ones generate.py renders
+// from schemas.go.j2 for the same resource.
+
+func elemType(name string) attr.Type {
+	if name == "Int64" {
+		return types.Int64Type
+	}
+	return types.StringType
+}
+
+func resourceSchema(r *resourceInfo) resourceschema.Schema {
+	attrs := map[string]resourceschema.Attribute{}
+	for _, f := range r.fields {
+		if !f.inSchema() {
+			continue
+		}
+		if f.readOnlyObject() {
+			// Read-only objects keep their state in plans, so they cause no diffs
+			attrs[f.tfName] = resourceschema.SingleNestedAttribute{
+				Computed:           true,
+				DeprecationMessage: f.deprecationMessage,
+				PlanModifiers:      []planmodifier.Object{objectplanmodifier.UseStateForUnknown()},
+				Attributes:         resourceComputedAttributes(f.nestedFields),
+			}
+			continue
+		}
+		if f.isNested && !f.isList {
+			attrs[f.tfName] = resourceschema.SingleNestedAttribute{
+				Required:           f.required,
+				Optional:           !f.required && f.optional,
+				Computed:           !f.required && f.computed,
+				DeprecationMessage: f.deprecationMessage,
+				Attributes:         resourceObjectAttributes(f.nestedFields),
+			}
+			continue
+		}
+		if f.isNested {
+			nested := map[string]resourceschema.Attribute{}
+			for _, nf := range f.nestedFields {
+				nested[nf.tfName] = resourceNestedAttribute(nf)
+			}
+			attrs[f.tfName] = resourceschema.SetNestedAttribute{
+				Required:           f.required,
+				Optional:           !f.required && f.optional,
+				Computed:           !f.required && f.computed,
+				DeprecationMessage: f.deprecationMessage,
+				NestedObject:       resourceschema.NestedAttributeObject{Attributes: nested},
+			}
+			continue
+		}
+		attrs[f.tfName] = resourceAttribute(f)
+	}
+	if r.storePostResponse {
+		attrs["invitation"] = resourceschema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Base64-encoded JSON of the POST response (contains registration info).",
+		}
+	}
+	if r.hasGroups {
+		attrs["groups"] = resourceschema.ListNestedAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Group memberships with optional expiry.",
+			NestedObject: resourceschema.NestedAttributeObject{
+				Attributes: map[string]resourceschema.Attribute{
+					"id": resourceschema.Int64Attribute{
+						Required:    true,
+						Description: "Group ID.",
+					},
+					"expires": resourceschema.Int64Attribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Expiry timestamp (0 = never).",
+					},
+				},
+			},
+		}
+	}
+	if r.hasTags {
+		attrs["tags_all"] = resourceschema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "All tags of the resource, including default_tags from the provider configuration.",
+		}
+	}
+	return resourceschema.Schema{
+		Description:        "Manages a Blastshield " + r.tfName + ".",
+		Version:            stateupgrade.SchemaVersion(r.schemaChanges),
+		DeprecationMessage: r.deprecationMessage,
+		Attributes:         attrs,
+	}
+}
+
+func resourceAttribute(f *field) resourceschema.Attribute {
+	required := f.required
+	optional := !required && f.optional
+	computed := !required && f.computed
+	switch {
+	case f.isList:
+		return resourceschema.SetAttribute{ElementType: elemType(f.elemType), Required: required, Optional: optional, Computed: computed, Sensitive: f.sensitive, DeprecationMessage: f.deprecationMessage}
+	case f.isMap:
+		return resourceschema.MapAttribute{ElementType: types.StringType, Required: required, Optional: optional, Computed: computed, Sensitive: f.sensitive, DeprecationMessage: f.deprecationMessage}
+	case f.tfType == "Int64":
+		return resourceschema.Int64Attribute{Required: required, Optional: optional, Computed: computed, Sensitive: f.sensitive, DeprecationMessage: f.deprecationMessage}
+	case f.tfType == "Bool":
+		return resourceschema.BoolAttribute{Required: required, Optional: optional, Computed: computed, Sensitive: f.sensitive, DeprecationMessage: f.deprecationMessage}
+	default:
+		return resourceschema.StringAttribute{Required: required, Optional: optional, Computed: computed, Sensitive: f.sensitive, DeprecationMessage: f.deprecationMessage}
+	}
+}
+
+// resourceNestedAttribute returns an attribute of a nested object, which is
+// required when the API requires it and optional otherwise.
+func resourceNestedAttribute(f *field) resourceschema.Attribute {
+	required := f.required
+	optional := !required
+	switch {
+	case f.isList:
+		return resourceschema.SetAttribute{ElementType: elemType(f.elemType), Required: required, Optional: optional, DeprecationMessage: f.deprecationMessage}
+	case f.tfType == "Int64":
+		return resourceschema.Int64Attribute{Required: required, Optional: optional, DeprecationMessage: f.deprecationMessage}
+	case f.tfType == "Bool":
+		return resourceschema.BoolAttribute{Required: required, Optional: optional, DeprecationMessage: f.deprecationMessage}
+	default:
+		return resourceschema.StringAttribute{Required: required, Optional: optional, DeprecationMessage: f.deprecationMessage}
+	}
+}
+
+// resourceObjectAttributes returns the attributes of a single nested object, which
+// are optional and computed unless required so that the API defaults apply.
+func resourceObjectAttributes(fields []*field) map[string]resourceschema.Attribute {
+	attrs := map[string]resourceschema.Attribute{}
+	for _, nf := range fields {
+		if nf.isNested {
+			nested := map[string]resourceschema.Attribute{}
+			for _, ef := range nf.nestedFields {
+				nested[ef.tfName] = resourceNestedAttribute(ef)
+			}
+			attrs[nf.tfName] = resourceschema.SetNestedAttribute{
+				Required:           nf.required,
+				Optional:           !nf.required,
+				Computed:           !nf.required,
+				DeprecationMessage: nf.deprecationMessage,
+				NestedObject:       resourceschema.NestedAttributeObject{Attributes: nested},
+			}
+			continue
+		}
+		of := *nf
+		of.optional = true
+		of.computed = true
+		attrs[nf.tfName] = resourceAttribute(&of)
+	}
+	return attrs
+}
+
+// resourceComputedAttributes returns the attributes of a read-only object, which
+// are all computed.
+func resourceComputedAttributes(fields []*field) map[string]resourceschema.Attribute {
+	attrs := map[string]resourceschema.Attribute{}
+	for _, nf := range fields {
+		of := *nf
+		of.required, of.optional, of.computed = false, false, true
+		if nf.isNested {
+			attrs[nf.tfName] = resourceschema.SetNestedAttribute{
+				Computed:           true,
+				DeprecationMessage: nf.deprecationMessage,
+				NestedObject:       resourceschema.NestedAttributeObject{Attributes: resourceComputedAttributes(elementFields(nf))},
+			}
+			continue
+		}
+		attrs[nf.tfName] = resourceAttribute(&of)
+	}
+	return attrs
+}
+
+// elementFields returns the attributes of the objects of a nested list, which
+// are never sensitive.
+func elementFields(f *field) []*field {
+	fields := make([]*field, 0, len(f.nestedFields))
+	for _, nf := range f.nestedFields {
+		fields = append(fields, &field{
+			tfName: nf.tfName, isList: nf.isList, elemType: nf.elemType, tfType: nf.tfType,
+			deprecationMessage: nf.deprecationMessage,
+		})
+	}
+	return fields
+}
+
+// computedAttribute returns the data source attribute of a field. The id of the
+// singular data source is the only required attribute.
+func computedAttribute(f *field, required bool) schema.Attribute {
+	computed := !required
+	sensitive := f.sensitive
+	deprecation := f.deprecationMessage
+	switch {
+	case f.isNested && !f.isList:
+		nested := map[string]schema.Attribute{}
+		for _, nf := range f.nestedFields {
+			nested[nf.tfName] = computedAttribute(nf, false)
+		}
+		return schema.SingleNestedAttribute{Computed: true, DeprecationMessage: deprecation, Attributes: nested}
+	case f.isNested:
+		nested := map[string]schema.Attribute{}
+		for _, nf := range elementFields(f) {
+			nested[nf.tfName] = computedAttribute(nf, false)
+		}
+		return schema.SetNestedAttribute{
+			Computed:           true,
+			DeprecationMessage: deprecation,
+			NestedObject:       schema.NestedAttributeObject{Attributes: nested},
+		}
+	case f.isList:
+		return schema.SetAttribute{ElementType: elemType(f.elemType), Required: required, Computed: computed, Sensitive: sensitive, DeprecationMessage: deprecation}
+	case f.isMap:
+		return schema.MapAttribute{ElementType: types.StringType, Required: required, Computed: computed, Sensitive: sensitive, DeprecationMessage: deprecation}
+	case f.tfType == "Int64":
+		return schema.Int64Attribute{Required: required, Computed: computed, Sensitive: sensitive, DeprecationMessage: deprecation}
+	case f.tfType == "Bool":
+		return schema.BoolAttribute{Required: required, Computed: computed, Sensitive: sensitive, DeprecationMessage: deprecation}
+	default:
+		return schema.StringAttribute{Required: required, Computed: computed, Sensitive: sensitive, DeprecationMessage: deprecation}
+	}
+}
+
+func dataSourceSchema(r *resourceInfo) schema.Schema {
+	attrs := map[string]schema.Attribute{}
+	for _, f := range r.fields {
+		if f.inSchema() {
+			attrs[f.tfName] = computedAttribute(f, f.jsonName == "id")
+		}
+	}
+	if r.storePostResponse {
+		attrs["invitation"] = schema.StringAttribute{
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Not available from data source (only returned on resource creation).",
+		}
+	}
+	if r.hasGroups {
+		attrs["groups"] = schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Group memberships with optional expiry.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Group ID.",
+					},
+					"expires": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Expiry timestamp (0 = never).",
+					},
+				},
+			},
+		}
+	}
+	if r.hasTags {
+		attrs["tags_all"] = schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "All tags of the " + r.tfName + ".",
+		}
+	}
+	return schema.Schema{
+		Description:        "Fetches a Blastshield " + r.tfName + " by ID.",
+		DeprecationMessage: r.deprecationMessage,
+		Attributes:         attrs,
+	}
+}
+
+func listDataSourceSchema(r *resourceInfo) schema.Schema {
+	attrs := map[string]schema.Attribute{}
+	for _, qp := range r.queryParams {
+		description := "Filter by " + qp.tfName + "."
+		switch {
+		case qp.isList:
+			attrs[qp.tfName] = schema.ListAttribute{ElementType: elemType(qp.tfType), Optional: true, Description: description}
+		case qp.tfType == "Int64":
+			attrs[qp.tfName] = schema.Int64Attribute{Optional: true, Description: description}
+		case qp.tfType == "Bool":
+			attrs[qp.tfName] = schema.BoolAttribute{Optional: true, Description: description}
+		default:
+			attrs[qp.tfName] = schema.StringAttribute{Optional: true, Description: description}
+		}
+	}
+
+	items := map[string]schema.Attribute{}
+	for _, f := range r.fields {
+		if f.inSchema() {
+			items[f.tfName] = computedAttribute(&field{
+				isList: f.isList, isMap: f.isMap, isNested: f.isNested,
+				elemType: f.elemType, tfType: f.tfType, nestedFields: f.nestedFields,
+				deprecationMessage: f.deprecationMessage,
+			}, false)
+		}
+	}
+	if r.storePostResponse {
+		items["invitation"] = schema.StringAttribute{
+			Computed:    true,
+			Description: "The invitation code (only available on resource creation).",
+		}
+	}
+	if r.hasGroups {
+		items["groups"] = schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Groups this resource belongs to.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.Int64Attribute{
+						Computed:    true,
+						Description: "The group ID.",
+					},
+					"expires": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Unix timestamp when group membership expires.",
+					},
+				},
+			},
+		}
+	}
+	if r.hasTags {
+		items["tags_all"] = schema.MapAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+			Description: "All tags of the " + r.tfName + ".",
+		}
+	}
+	attrs[r.tfPluralName] = schema.ListNestedAttribute{
+		Computed:     true,
+		Description:  "List of " + r.tfPluralName + " matching the filters.",
+		NestedObject: schema.NestedAttributeObject{Attributes: items},
+	}
+
+	return schema.Schema{
+		Description:        "Lists Blastshield " + r.tfName + "s with optional filters.",
+		DeprecationMessage: r.deprecationMessage,
+		Attributes:         attrs,
+	}
+}
@@ -0,0 +1,615 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/stateupgrade"
+)
+
+// The customizations below mirror generate.py so that the dynamic schema matches
+// the schema the generated packages would have for the same spec.
+var (
+	// skipTags are API tags that are not exposed as resources
+	skipTags = map[string]bool{"API Keys": true, "Audit": true, "APIKeys": true}
+
+	// resourcesWithGroups have a separate /resource/{id}/groups endpoint
+	resourcesWithGroups = map[string]bool{"Node": true, "Endpoint": true}
+
+	// storePostResponse resources keep the POST response as base64-encoded JSON
+	storePostResponse = map[string]bool{"Node": true}
+
+	// postResponseIDField is the field of the POST response holding the entity ID
+	postResponseIDField = map[string]string{"Node": "node_id"}
+
+	// nullableRequiredFields are required by the API but accept null for auto-assignment
+	nullableRequiredFields = map[string][]string{"Endpoint": {"address"}}
+
+	// sensitiveFields are marked sensitive in the schema
+	sensitiveFields = map[string]bool{
+		"password": true, "token": true, "secret": true,
+		"registration_token": true, "key": true, "key_hash": true,
+	}
+)
+
+// openAPISpec is the part of an OpenAPI document the provider reads.
+type openAPISpec struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+	// Paths keeps the document order, which decides each resource's base path
+	Paths      orderedObject `json:"paths"`
+	Components struct {
+		Schemas map[string]*schemaObject `json:"schemas"`
+	} `json:"components"`
+}
+
+type schemaObject struct {
+	Type       string          `json:"type"`
+	Enum       []interface{}   `json:"enum"`
+	Ref        string          `json:"$ref"`
+	AnyOf      []*schemaObject `json:"anyOf"`
+	Items      *schemaObject   `json:"items"`
+	Properties orderedObject   `json:"properties"`
+	Required   []string        `json:"required"`
+	Deprecated bool            `json:"deprecated"`
+}
+
+type operation struct {
+	Tags       []string `json:"tags"`
+	Deprecated bool     `json:"deprecated"`
+	Parameters []struct {
+		Name   string        `json:"name"`
+		In     string        `json:"in"`
+		Schema *schemaObject `json:"schema"`
+	} `json:"parameters"`
+	RequestBody struct {
+		Content map[string]struct {
+			Schema *schemaObject `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *schemaObject `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+// orderedObject is a JSON object whose members keep their document order.
+type orderedObject []member
+
+type member struct {
+	Key   string
+	Value json.RawMessage
+}
+
+func (o *orderedObject) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		*o = append(*o, member{Key: tok.(string), Value: value})
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// field is an attribute of a resource, parsed from an OpenAPI property.
+type field struct {
+	jsonName string
+	tfName   string
+	// tfType is String, Int64, Bool, List, Map or Object
+	tfType string
+	// elemType is the element type of a list of scalars, String or Int64
+	elemType     string
+	isPointer    bool
+	isList       bool
+	isMap        bool
+	isNested     bool
+	nestedFields []*field
+
+	required  bool
+	optional  bool
+	computed  bool
+	sensitive bool
+	// deprecated is set when the property is flagged deprecated: true
+	deprecated         bool
+	deprecationMessage string
+}
+
+// inSchema reports whether the field is an attribute of the Terraform schema.
+// Nested objects without attributes are left out, as in the generated schemas.
+func (f *field) inSchema() bool {
+	if f.isNested {
+		return len(f.nestedFields) > 0
+	}
+	return true
+}
+
+// readOnlyObject reports whether the field is a single nested object that is
+// only returned by the API, such as status.
+func (f *field) readOnlyObject() bool {
+	return f.isNested && !f.isList && f.computed && !f.optional
+}
+
+type queryParam struct {
+	name   string
+	tfName string
+	tfType string
+	isList bool
+}
+
+// resourceInfo describes a resource and its data sources.
+type resourceInfo struct {
+	name         string
+	tfName       string
+	tfPluralName string
+	path         string
+	// idType is string or int64
+	idType       string
+	fields       []*field
+	createFields []*field
+	queryParams  []queryParam
+
+	hasGroups         bool
+	hasTags           bool
+	storePostResponse bool
+	postIDField       string
+	nullableRequired  map[string]bool
+	// deprecated is set when an operation of the base path is flagged deprecated: true
+	deprecated         bool
+	deprecationMessage string
+	// subEndpoints are the paths below an entity, such as /groups, sorted
+	subEndpoints []string
+
+	// schemaChanges are inherited from the compiled version, see InheritSchemaChanges
+	schemaChanges []stateupgrade.Change
+}
+
+var camelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// toTFName converts PascalCase, snake_case and kebab-case names to snake_case.
+func toTFName(name string) string {
+	s := camelBoundary.ReplaceAllString(name, "${1}_${2}")
+	return strings.ReplaceAll(strings.ToLower(s), "-", "_")
+}
+
+func toTFPluralName(name string) string {
+	name = toTFName(name)
+	if strings.HasSuffix(name, "y") {
+		return name[:len(name)-1] + "ies"
+	}
+	return name + "s"
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func parseField(prop *schemaObject, name string, schemas map[string]*schemaObject) (*field, error) {
+	f := &field{
+		jsonName: name,
+		tfName:   toTFName(name),
+		tfType:   "String",
+	}
+	if prop == nil {
+		prop = &schemaObject{}
+	}
+	f.deprecated = prop.Deprecated
+
+	if len(prop.AnyOf) > 0 {
+		for _, t := range prop.AnyOf {
+			if t.Type != "null" {
+				prop = t
+				f.isPointer = true
+				break
+			}
+		}
+	}
+
+	if prop.Ref != "" {
+		ref, ok := schemas[refName(prop.Ref)]
+		if !ok {
+			return f, nil
+		}
+		if ref.Type == "string" && ref.Enum != nil {
+			return f, nil
+		}
+		f.isNested = true
+		f.tfType = "Object"
+		nested, err := parseNestedFields(ref, schemas, true)
+		if err != nil {
+			return nil, err
+		}
+		f.nestedFields = nested
+		return f, nil
+	}
+
+	switch prop.Type {
+	case "integer":
+		f.tfType = "Int64"
+	case "boolean":
+		f.tfType = "Bool"
+	case "array":
+		f.isList = true
+		f.tfType = "List"
+		f.elemType = "String"
+		items := prop.Items
+		if items == nil {
+			items = &schemaObject{}
+		}
+		for _, t := range items.AnyOf {
+			if t.Ref != "" {
+				items = t
+				break
+			} else if t.Type != "" && t.Type != "null" {
+				items = t
+			}
+		}
+		switch {
+		case items.Type == "integer":
+			f.elemType = "Int64"
+		case items.Ref != "":
+			ref, ok := schemas[refName(items.Ref)]
+			if !ok || (ref.Type == "string" && ref.Enum != nil) {
+				break
+			}
+			if ref.Type == "integer" {
+				f.elemType = "Int64"
+				break
+			}
+			f.isNested = true
+			f.elemType = ""
+			nested, err := parseNestedFields(ref, schemas, false)
+			if err != nil {
+				return nil, err
+			}
+			f.nestedFields = nested
+		}
+	case "object":
+		f.isMap = true
+		f.tfType = "Map"
+	}
+
+	f.sensitive = sensitiveFields[name]
+	return f, nil
+}
+
+// parseNestedFields parses the properties of a nested object. Only scalars and
+// lists of scalars can be attributes of an object in a nested list; a single
+// nested object can also have maps and nested lists. Other properties are left
+// out.
+func parseNestedFields(s *schemaObject, schemas map[string]*schemaObject, object bool) ([]*field, error) {
+	required := stringSet(s.Required)
+	var fields []*field
+	for _, m := range s.Properties {
+		var prop schemaObject
+		if err := json.Unmarshal(m.Value, &prop); err != nil {
+			return nil, fmt.Errorf("parsing property %s: %w", m.Key, err)
+		}
+		f, err := parseField(&prop, m.Key, schemas)
+		if err != nil {
+			return nil, err
+		}
+		nestedList := object && f.isList && len(f.nestedFields) > 0
+		if f.isNested && !nestedList || f.isMap && !object {
+			continue
+		}
+		f.required = required[m.Key]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func parseSchemaFields(spec *openAPISpec, schemaName string) ([]*field, error) {
+	schemas := spec.Components.Schemas
+	s, ok := schemas[schemaName]
+	if !ok {
+		return nil, nil
+	}
+
+	required := stringSet(s.Required)
+	var fields []*field
+	for _, m := range s.Properties {
+		var prop schemaObject
+		if err := json.Unmarshal(m.Value, &prop); err != nil {
+			return nil, fmt.Errorf("parsing %s.%s: %w", schemaName, m.Key, err)
+		}
+		f, err := parseField(&prop, m.Key, schemas)
+		if err != nil {
+			return nil, err
+		}
+		f.required = required[m.Key]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func parseQueryParams(op *operation) []queryParam {
+	if op == nil {
+		return nil
+	}
+	var params []queryParam
+	for _, p := range op.Parameters {
+		if p.In != "query" || strings.Contains(p.Name, ".") {
+			continue
+		}
+		s := p.Schema
+		if s == nil {
+			s = &schemaObject{}
+		}
+		paramType := s.Type
+		isList := paramType == "array"
+		if isList {
+			paramType = ""
+			if s.Items != nil {
+				paramType = s.Items.Type
+			}
+		}
+		tfType := "String"
+		switch paramType {
+		case "integer":
+			tfType = "Int64"
+		case "boolean":
+			tfType = "Bool"
+		}
+		params = append(params, queryParam{
+			name:   p.Name,
+			tfName: toTFName(p.Name),
+			tfType: tfType,
+			isList: isList,
+		})
+	}
+	return params
+}
+
+func jsonSchema(content map[string]struct {
+	Schema *schemaObject `json:"schema"`
+}) *schemaObject {
+	if s := content["application/json"].Schema; s != nil {
+		return s
+	}
+	return &schemaObject{}
+}
+
+// findResources finds the resources of a spec the way generate.py does: one per
+// API tag, named after the tag, with its schema taken from the list response and
+// the create request of the tag's collection paths.
+func findResources(spec *openAPISpec) ([]*resourceInfo, error) {
+	type tagPaths struct {
+		basePath string
+		methods  map[string]*operation
+	}
+	var tags []string
+	byTag := map[string]*tagPaths{}
+	basePaths := map[string]map[string]*operation{}
+
+	for _, p := range spec.Paths {
+		if strings.Contains(p.Key, "{") {
+			continue
+		}
+		var methods map[string]*operation
+		if err := json.Unmarshal(p.Value, &methods); err != nil {
+			return nil, fmt.Errorf("parsing path %s: %w", p.Key, err)
+		}
+		basePaths[p.Key] = methods
+		for _, method := range []string{"get", "post"} {
+			op, ok := methods[method]
+			if !ok || op == nil || len(op.Tags) == 0 || skipTags[op.Tags[0]] {
+				continue
+			}
+			tag := op.Tags[0]
+			if byTag[tag] == nil {
+				byTag[tag] = &tagPaths{basePath: p.Key, methods: map[string]*operation{}}
+				tags = append(tags, tag)
+			}
+			if method == "post" && jsonSchema(op.RequestBody.Content).Type == "array" {
+				continue
+			}
+			byTag[tag].methods[method] = op
+		}
+	}
+
+	var resources []*resourceInfo
+	for _, tag := range tags {
+		info := byTag[tag]
+
+		name := strings.TrimRight(tag, "s")
+		if strings.HasSuffix(name, "ie") {
+			name = name[:len(name)-2] + "y"
+		}
+		name = strings.ReplaceAll(name, " ", "")
+
+		entitySchema := ""
+		if get := info.methods["get"]; get != nil {
+			s := jsonSchema(get.Responses["200"].Content)
+			if s.Type == "array" && s.Items != nil && s.Items.Ref != "" {
+				entitySchema = refName(s.Items.Ref)
+			}
+		}
+		if entitySchema == "" {
+			continue
+		}
+
+		createSchema := ""
+		if post := info.methods["post"]; post != nil {
+			if s := jsonSchema(post.RequestBody.Content); s.Ref != "" {
+				createSchema = refName(s.Ref)
+			}
+		}
+
+		fields, err := parseSchemaFields(spec, entitySchema)
+		if err != nil {
+			return nil, err
+		}
+		var createFields []*field
+		if createSchema != "" {
+			createFields, err = parseSchemaFields(spec, createSchema)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		var idField *field
+		for _, f := range fields {
+			if f.jsonName == "id" {
+				idField = f
+			}
+		}
+		if idField == nil {
+			// Without an ID there is no path to read the entity back
+			continue
+		}
+		idType := "int64"
+		if idField.tfType == "String" {
+			idType = "string"
+		}
+
+		createNames := map[string]bool{}
+		requiredCreate := map[string]bool{}
+		for _, f := range createFields {
+			createNames[f.jsonName] = true
+			requiredCreate[f.jsonName] = f.required
+		}
+		nullableRequired := stringSet(nullableRequiredFields[name])
+
+		for _, f := range fields {
+			f.required, f.optional, f.computed = false, false, false
+			switch {
+			case f.jsonName == "id":
+				f.computed = true
+			case nullableRequired[f.jsonName]:
+				f.optional, f.computed = true, true
+			case requiredCreate[f.jsonName]:
+				f.required = true
+			case createNames[f.jsonName]:
+				f.optional, f.computed = true, true
+			default:
+				f.computed = true
+			}
+		}
+
+		var listOp *operation
+		if methods := basePaths[info.basePath]; methods != nil {
+			listOp = methods["get"]
+		}
+
+		postIDField := postResponseIDField[name]
+		if postIDField == "" {
+			postIDField = "id"
+		}
+
+		resources = append(resources, &resourceInfo{
+			name:              name,
+			tfName:            toTFName(name),
+			tfPluralName:      toTFPluralName(name),
+			path:              info.basePath,
+			idType:            idType,
+			fields:            fields,
+			createFields:      createFields,
+			queryParams:       parseQueryParams(listOp),
+			hasGroups:         resourcesWithGroups[name],
+			hasTags:           createNames["tags"],
+			storePostResponse: storePostResponse[name],
+			postIDField:       postIDField,
+			nullableRequired:  nullableRequired,
+			subEndpoints:      subEndpoints(spec, info.basePath),
+			deprecated:        info.methods["get"].isDeprecated() || info.methods["post"].isDeprecated(),
+		})
+	}
+	setDeprecations(resources, spec.Info.Version)
+	return resources, nil
+}
+
+func (op *operation) isDeprecated() bool {
+	return op != nil && op.Deprecated
+}
+
+// setDeprecations sets the deprecation messages of what the document flags
+// deprecated. Unlike generate.py, there is no next version to compare with.
+func setDeprecations(resources []*resourceInfo, version string) {
+	deprecated := fmt.Sprintf("Deprecated in Blastshield API version %s.", version)
+	for _, r := range resources {
+		deprecateFlagged(r.fields, deprecated)
+		if r.deprecated {
+			r.deprecationMessage = deprecated
+		}
+	}
+}
+
+// deprecateFlagged sets message on the fields, nested ones included, flagged
+// deprecated: true.
+func deprecateFlagged(fields []*field, message string) {
+	for _, f := range fields {
+		if f.deprecated {
+			f.deprecationMessage = message
+		}
+		deprecateFlagged(f.nestedFields, message)
+	}
+}
+
+// subEndpoints returns the sorted paths below the entities of basePath, such as
+// /groups for /nodes/{id}/groups.
+func subEndpoints(spec *openAPISpec, basePath string) []string {
+	var endpoints []string
+	for _, p := range spec.Paths {
+		rest, ok := strings.CutPrefix(p.Key, basePath+"{")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(rest, "}/"); i >= 0 && !strings.Contains(rest[:i], "/") {
+			endpoints = append(endpoints, rest[i+1:])
+		}
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// field returns the entity field with the given JSON name, or nil.
+func (r *resourceInfo) field(jsonName string) *field {
+	for _, f := range r.fields {
+		if f.jsonName == jsonName {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// The conversions below follow the response mapping of the generated resources
+// (macros.j2): lists and maps missing from a response become empty rather than
+// null, and scalars are null only when the API declares them nullable.
+
+// entity is a JSON object returned by the API, decoded one field at a time.
+type entity map[string]json.RawMessage
+
+// groupMembership is an entry of a resource's /groups endpoint.
+type groupMembership struct {
+	ID      int64 `json:"id"`
+	Expires int64 `json:"expires"`
+}
+
+var (
+	stringMapType = tftypes.Map{ElementType: tftypes.String}
+	groupsType    = tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":      tftypes.Number,
+		"expires": tftypes.Number,
+	}}}
+)
+
+func scalarType(tfType string) tftypes.Type {
+	switch tfType {
+	case "Int64":
+		return tftypes.Number
+	case "Bool":
+		return tftypes.Bool
+	default:
+		return tftypes.String
+	}
+}
+
+func nestedObjectType(f *field) tftypes.Object {
+	attrs := make(map[string]tftypes.Type, len(f.nestedFields))
+	for _, nf := range f.nestedFields {
+		attrs[nf.tfName] = fieldType(nf)
+	}
+	return tftypes.Object{AttributeTypes: attrs}
+}
+
+// fieldType returns the Terraform type of a field's attribute.
+func fieldType(f *field) tftypes.Type {
+	switch {
+	case f.isNested && !f.isList:
+		return nestedObjectType(f)
+	case f.isNested:
+		return tftypes.Set{ElementType: nestedObjectType(f)}
+	case f.isList:
+		return tftypes.Set{ElementType: scalarType(f.elemType)}
+	case f.isMap:
+		return stringMapType
+	default:
+		return scalarType(f.tfType)
+	}
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || string(raw) == "null"
+}
+
+// known reports whether v holds a value that can be sent to the API.
+func known(v tftypes.Value) bool {
+	return v.IsKnown() && !v.IsNull()
+}
+
+// fromAPI converts a field of an API response to its Terraform value.
+func fromAPI(f *field, raw json.RawMessage) (tftypes.Value, error) {
+	typ := fieldType(f)
+	switch {
+	case f.isNested && !f.isList:
+		return objectFromAPI(f, raw)
+	case f.isNested:
+		var items []entity
+		if !isJSONNull(raw) {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+			}
+		}
+		objType := nestedObjectType(f)
+		elems := make([]tftypes.Value, 0, len(items))
+		for _, item := range items {
+			attrs := make(map[string]tftypes.Value, len(f.nestedFields))
+			for _, nf := range f.nestedFields {
+				v, err := nestedFromAPI(nf, item[nf.jsonName])
+				if err != nil {
+					return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+				}
+				attrs[nf.tfName] = v
+			}
+			elems = append(elems, tftypes.NewValue(objType, attrs))
+		}
+		return tftypes.NewValue(typ, elems), nil
+	case f.isList:
+		return listFromAPI(f, raw)
+	case f.isMap:
+		m := map[string]string{}
+		if !isJSONNull(raw) {
+			if err := json.Unmarshal(raw, &m); err != nil {
+				return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+			}
+		}
+		return stringMapValue(m), nil
+	default:
+		return scalarFromAPI(f, raw)
+	}
+}
+
+// nestedFromAPI converts an attribute of a nested object. A list missing from the
+// response is null inside a nested object.
+func nestedFromAPI(f *field, raw json.RawMessage) (tftypes.Value, error) {
+	if f.isList && isJSONNull(raw) {
+		return tftypes.NewValue(fieldType(f), nil), nil
+	}
+	if f.isList {
+		return listFromAPI(f, raw)
+	}
+	return scalarFromAPI(f, raw)
+}
+
+// objectFromAPI converts a single nested object, which is null when the API
+// declares it nullable and leaves it out. Inside the object, lists and maps
+// missing from the response are null, while nested lists are empty.
+func objectFromAPI(f *field, raw json.RawMessage) (tftypes.Value, error) {
+	typ := fieldType(f)
+	if isJSONNull(raw) && f.isPointer {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	item := entity{}
+	if !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+		}
+	}
+	attrs := make(map[string]tftypes.Value, len(f.nestedFields))
+	for _, nf := range f.nestedFields {
+		var v tftypes.Value
+		var err error
+		switch {
+		case nf.isMap && isJSONNull(item[nf.jsonName]):
+			v = tftypes.NewValue(stringMapType, nil)
+		case nf.isNested || nf.isMap:
+			v, err = fromAPI(nf, item[nf.jsonName])
+		default:
+			v, err = nestedFromAPI(nf, item[nf.jsonName])
+		}
+		if err != nil {
+			return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+		}
+		attrs[nf.tfName] = v
+	}
+	return tftypes.NewValue(typ, attrs), nil
+}
+
+func listFromAPI(f *field, raw json.RawMessage) (tftypes.Value, error) {
+	var items []json.RawMessage
+	if !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+		}
+	}
+	elem := &field{jsonName: f.jsonName, tfType: f.elemType}
+	elems := make([]tftypes.Value, 0, len(items))
+	for _, item := range items {
+		v, err := scalarFromAPI(elem, item)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		elems = append(elems, v)
+	}
+	return tftypes.NewValue(fieldType(f), elems), nil
+}
+
+func scalarFromAPI(f *field, raw json.RawMessage) (tftypes.Value, error) {
+	typ := scalarType(f.tfType)
+	if isJSONNull(raw) && f.isPointer {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	if isJSONNull(raw) {
+		raw = nil
+	}
+
+	var err error
+	var value interface{}
+	switch f.tfType {
+	case "Int64":
+		var n int64
+		if raw != nil {
+			err = json.Unmarshal(raw, &n)
+		}
+		value = new(big.Float).SetInt64(n)
+	case "Bool":
+		var b bool
+		if raw != nil {
+			err = json.Unmarshal(raw, &b)
+		}
+		value = b
+	default:
+		var s string
+		if raw != nil {
+			err = json.Unmarshal(raw, &s)
+		}
+		value = s
+	}
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding %s: %w", f.jsonName, err)
+	}
+	return tftypes.NewValue(typ, value), nil
+}
+
+// toAPI converts a known Terraform value to the JSON value sent to the API.
+func toAPI(f *field, v tftypes.Value) (interface{}, error) {
+	switch {
+	case f.isNested && !f.isList:
+		return objectToAPI(f.nestedFields, v)
+	case f.isNested:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		items := make([]map[string]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			var attrs map[string]tftypes.Value
+			if err := elem.As(&attrs); err != nil {
+				return nil, err
+			}
+			item := map[string]interface{}{}
+			for _, nf := range f.nestedFields {
+				nv := attrs[nf.tfName]
+				if nf.isList {
+					if !known(nv) {
+						continue
+					}
+					list, err := toAPI(nf, nv)
+					if err != nil {
+						return nil, err
+					}
+					item[nf.jsonName] = list
+					continue
+				}
+				value, err := scalarToAPI(nf.tfType, nv)
+				if err != nil {
+					return nil, err
+				}
+				item[nf.jsonName] = value
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case f.isList:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			value, err := scalarToAPI(f.elemType, elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case f.isMap:
+		return stringMap(v)
+	default:
+		return scalarToAPI(f.tfType, v)
+	}
+}
+
+// scalarToAPI returns the value of a scalar, or its zero value when it is null
+// or unknown.
+func scalarToAPI(tfType string, v tftypes.Value) (interface{}, error) {
+	switch tfType {
+	case "Int64":
+		if !known(v) {
+			return int64(0), nil
+		}
+		var f big.Float
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		n, _ := f.Int64()
+		return n, nil
+	case "Bool":
+		if !known(v) {
+			return false, nil
+		}
+		var b bool
+		err := v.As(&b)
+		return b, err
+	default:
+		if !known(v) {
+			return "", nil
+		}
+		var s string
+		err := v.As(&s)
+		return s, err
+	}
+}
+
+// stringMap returns the elements of a map of strings. Null and unknown maps
+// return nil.
+func stringMap(v tftypes.Value) (map[string]string, error) {
+	if !known(v) {
+		return nil, nil
+	}
+	var elems map[string]tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(elems))
+	for k, elem := range elems {
+		var s string
+		if err := elem.As(&s); err != nil {
+			return nil, err
+		}
+		m[k] = s
+	}
+	return m, nil
+}
+
+// entityTags returns the tags of an API response, or nil when it has none.
+func entityTags(ent entity) (map[string]string, error) {
+	var tags map[string]string
+	if raw := ent["tags"]; !isJSONNull(raw) {
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+// stringMapValue returns a Terraform map of strings; a nil map is null.
+func stringMapValue(m map[string]string) tftypes.Value {
+	if m == nil {
+		return tftypes.NewValue(stringMapType, nil)
+	}
+	elems := make(map[string]tftypes.Value, len(m))
+	for k, v := range m {
+		elems[k] = tftypes.NewValue(tftypes.String, v)
+	}
+	return tftypes.NewValue(stringMapType, elems)
+}
+
+// groupsFromTerraform returns the planned group memberships, or nil when groups
+// is null or unknown.
+func groupsFromTerraform(v tftypes.Value) ([]groupMembership, error) {
+	if !known(v) {
+		return nil, nil
+	}
+	var elems []tftypes.Value
+	if err := v.As(&elems); err != nil {
+		return nil, err
+	}
+	groups := make([]groupMembership, 0, len(elems))
+	for _, elem := range elems {
+		var attrs map[string]tftypes.Value
+		if err := elem.As(&attrs); err != nil {
+			return nil, err
+		}
+		id, err := scalarToAPI("Int64", attrs["id"])
+		if err != nil {
+			return nil, err
+		}
+		expires, err := scalarToAPI("Int64", attrs["expires"])
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, groupMembership{ID: id.(int64), Expires: expires.(int64)})
+	}
+	return groups, nil
+}
+
+// groupsToTerraform converts group memberships to the groups attribute; nil is null.
+func groupsToTerraform(groups []groupMembership) tftypes.Value {
+	if groups == nil {
+		return tftypes.NewValue(groupsType, nil)
+	}
+	objType := groupsType.ElementType
+	elems := make([]tftypes.Value, len(groups))
+	for i, g := range groups {
+		elems[i] = tftypes.NewValue(objType, map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(g.ID)),
+			"expires": tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(g.Expires)),
+		})
+	}
+	return tftypes.NewValue(groupsType, elems)
+}
+
+// objectToAPI converts a single nested object to the body sent to the API. Only
+// the attributes that are known are sent, so the API applies its defaults; this
+// also holds for the objects of its nested lists.
+func objectToAPI(fields []*field, v tftypes.Value) (map[string]interface{}, error) {
+	var attrs map[string]tftypes.Value
+	if err := v.As(&attrs); err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	for _, nf := range fields {
+		nv := attrs[nf.tfName]
+		if !known(nv) {
+			continue
+		}
+		if !nf.isNested {
+			value, err := toAPI(nf, nv)
+			if err != nil {
+				return nil, err
+			}
+			body[nf.jsonName] = value
+			continue
+		}
+		var elems []tftypes.Value
+		if err := nv.As(&elems); err != nil {
+			return nil, err
+		}
+		items := make([]map[string]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			item, err := objectToAPI(nf.nestedFields, elem)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		body[nf.jsonName] = items
+	}
+	return body, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/dynamic"
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// The dynamic version must serve exactly the schemas generate.py produces for the
+// same spec, otherwise a server would change schema when a compiled package for
+// its version is released. Only the newest spec is compared, because the packages
+// of older ones also deprecate what the next spec removes.
+func TestDynamicSchema_MatchesGenerated(t *testing.T) {
+	ctx := context.Background()
+	specs, err := filepath.Glob("../../openapi-specs/*.json")
+	if err != nil || len(specs) == 0 {
+		t.Fatalf("no OpenAPI specs found: %v", err)
+	}
+	_, latest := versions.LatestVersion()
+
+	for _, file := range specs {
+		spec, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vp, err := dynamic.New(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if vp.Version() != latest {
+			continue
+		}
+		generated, ok := versions.Lookup(vp.Version())
+		if !ok {
+			t.Fatalf("no generated package registered for %s", vp.Version())
+		}
+
+		vp.InheritSchemaChanges(generated.Resources())
+
+		t.Run(vp.Version(), func(t *testing.T) {
+			want := resourceSchemas(ctx, generated.Resources())
+			got := resourceSchemas(ctx, vp.Resources())
+			if !reflect.DeepEqual(sortedKeys(got), sortedKeys(want)) {
+				t.Fatalf("resources: got %v, want %v", sortedKeys(got), sortedKeys(want))
+			}
+			for name, s := range want {
+				if !reflect.DeepEqual(got[name], s) {
+					t.Errorf("%s: resource schema differs from the generated one", name)
+				}
+			}
+
+			if !reflect.DeepEqual(vp.Capabilities(), generated.Capabilities()) {
+				t.Errorf("capabilities: got %+v, want %+v", vp.Capabilities(), generated.Capabilities())
+			}
+
+			wantDS := dataSourceSchemas(ctx, generated.DataSources())
+			gotDS := dataSourceSchemas(ctx, vp.DataSources())
+			if !reflect.DeepEqual(sortedKeys(gotDS), sortedKeys(wantDS)) {
+				t.Fatalf("data sources: got %v, want %v", sortedKeys(gotDS), sortedKeys(wantDS))
+			}
+			for name, s := range wantDS {
+				if !reflect.DeepEqual(gotDS[name], s) {
+					t.Errorf("%s: data source schema differs from the generated one", name)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterDynamicVersion_SkipsCompiledVersions(t *testing.T) {
+	spec, err := os.ReadFile("../../openapi-specs/1.13.0.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ver, err := registerDynamicVersion(spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ver != "" {
+		t.Errorf("expected no dynamic version when a compiled package is as new, got %s", ver)
+	}
+}
+
+func TestRegisterDynamicVersion_Disabled(t *testing.T) {
+	t.Setenv(DynamicSchemaEnv, "")
+	t.Setenv("BLASTSHIELD_HOST", "http://127.0.0.1:1")
+
+	ver, err := RegisterDynamicVersion(context.Background(), "test")
+	if err != nil || ver != "" {
+		t.Errorf("expected nothing to happen, got %q, %v", ver, err)
+	}
+}
+
+func TestDynamicSpec_UsesCache(t *testing.T) {
+	_, latest := versions.LatestVersion()
+	if latest == "" {
+		t.Skip("no API versions compiled in")
+	}
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"info": {"version": "` + latest + `"}}`))
+	})
+	cache := &versionCache{path: filepath.Join(t.TempDir(), "api-versions.json"), ttl: time.Hour, now: time.Now}
+	ctx := context.Background()
+
+	// A server no newer than the compiled versions needs no dynamic version, and
+	// the next start does not ask again
+	for i := 0; i < 2; i++ {
+		if spec, err := dynamicSpec(ctx, client, cache); err != nil || spec != nil {
+			t.Fatalf("expected no document, got %d bytes, %v", len(spec), err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", calls.Load())
+	}
+
+	// A newer server's document is served from the cache
+	want := []byte(`{"info": {"version": "99.0.0"}}`)
+	if err := cache.putSpec(client.Host, "99.0.0", want); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := dynamicSpec(ctx, client, cache)
+	if err != nil || string(spec) != string(want) {
+		t.Errorf("expected the cached document, got %q, %v", spec, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected no further requests, got %d", calls.Load())
+	}
+}
+
+func TestRegisterDynamicVersion_UnreachableHost(t *testing.T) {
+	clearConnectionEnv(t)
+	t.Setenv(DynamicSchemaEnv, "true")
+	t.Setenv("BLASTSHIELD_HOST", "http://127.0.0.1:1")
+	t.Setenv("BLASTSHIELD_TOKEN", "test-token")
+	t.Setenv("TF_PLUGIN_CACHE_DIR", t.TempDir())
+
+	start := time.Now()
+	ver, err := RegisterDynamicVersion(context.Background(), "test")
+	if err == nil || ver != "" {
+		t.Errorf("expected an error and no version, got %q, %v", ver, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected to give up without retrying, took %s", elapsed)
+	}
+}
+
+func resourceSchemas(ctx context.Context, factories []func() resource.Resource) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for _, factory := range factories {
+		r := factory()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerTypeName}, &meta)
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		schemas[meta.TypeName] = resp.Schema
+	}
+	return schemas
+}
+
+func dataSourceSchemas(ctx context.Context, factories []func() datasource.DataSource) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for _, factory := range factories {
+		d := factory()
+		var meta datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: providerTypeName}, &meta)
+		var resp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+		schemas[meta.TypeName] = resp.Schema
+	}
+	return schemas
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
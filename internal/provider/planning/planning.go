@@ -0,0 +1,76 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package planning implements the plan changes every Blastshield resource makes in
+// ModifyPlan. It is shared by the generated version packages and the dynamic package.
+package planning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RejectReadOnlyChange adds an error when the plan creates, updates or deletes a
+// resource. Resources call it when the provider is read-only.
+func RejectReadOnlyChange(state tfsdk.State, plan tfsdk.Plan, typeName string, diags *diag.Diagnostics) {
+	var action string
+	switch {
+	case state.Raw.IsNull() && plan.Raw.IsNull():
+		return
+	case state.Raw.IsNull():
+		action = "create"
+	case plan.Raw.IsNull():
+		action = "delete"
+	case !plan.Raw.Equal(state.Raw):
+		action = "update"
+	default:
+		return
+	}
+	diags.AddError(
+		"Provider Is Read-Only",
+		fmt.Sprintf("Cannot %s %s: the provider is configured with read_only = true, which refuses every change.", action, typeName),
+	)
+}
+
+// PlanTagsAll sets tags_all in plan from the resource's planned tags and the
+// provider's default_tags. tags_all stays unknown while tags are unknown.
+func PlanTagsAll(ctx context.Context, tagConfig *tagging.Config, plan *tfsdk.Plan, diags *diag.Diagnostics) {
+	var tags types.Map
+	d := plan.GetAttribute(ctx, path.Root("tags"), &tags)
+	diags.Append(d...)
+	if d.HasError() || tags.IsUnknown() {
+		return
+	}
+	var ownTags map[string]string
+	if !tags.IsNull() {
+		d = tags.ElementsAs(ctx, &ownTags, false)
+		diags.Append(d...)
+		if d.HasError() {
+			return
+		}
+	}
+
+	tagsAll, d := types.MapValueFrom(ctx, types.StringType, tagConfig.All(ownTags))
+	diags.Append(d...)
+	if d.HasError() {
+		return
+	}
+	diags.Append(plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
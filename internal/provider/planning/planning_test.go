@@ -0,0 +1,82 @@
+package planning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name":     schema.StringAttribute{Optional: true},
+		"tags":     schema.MapAttribute{Optional: true, ElementType: types.StringType},
+		"tags_all": schema.MapAttribute{Computed: true, ElementType: types.StringType},
+	},
+}
+
+var tagsType = tftypes.Map{ElementType: tftypes.String}
+
+func testValue(name string, tags tftypes.Value) tftypes.Value {
+	typ := testSchema.Type().TerraformType(context.Background())
+	return tftypes.NewValue(typ, map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, name),
+		"tags":     tags,
+		"tags_all": tftypes.NewValue(tagsType, tftypes.UnknownValue),
+	})
+}
+
+func TestRejectReadOnlyChange(t *testing.T) {
+	null := tftypes.NewValue(testSchema.Type().TerraformType(context.Background()), nil)
+	noTags := tftypes.NewValue(tagsType, nil)
+	tests := map[string]struct {
+		state, plan tftypes.Value
+		wantError   bool
+	}{
+		"create":    {null, testValue("a", noTags), true},
+		"update":    {testValue("a", noTags), testValue("b", noTags), true},
+		"delete":    {testValue("a", noTags), null, true},
+		"no change": {testValue("a", noTags), testValue("a", noTags), false},
+	}
+	for name, tt := range tests {
+		var diags diag.Diagnostics
+		RejectReadOnlyChange(tfsdk.State{Schema: testSchema, Raw: tt.state}, tfsdk.Plan{Schema: testSchema, Raw: tt.plan}, "blastshield_node", &diags)
+		if diags.HasError() != tt.wantError {
+			t.Errorf("%s: expected an error %t, got %v", name, tt.wantError, diags)
+		}
+	}
+}
+
+func TestPlanTagsAll(t *testing.T) {
+	ctx := context.Background()
+	tagConfig := &tagging.Config{DefaultTags: map[string]string{"owner": "netops"}}
+
+	plan := tfsdk.Plan{Schema: testSchema, Raw: testValue("a", tftypes.NewValue(tagsType, map[string]tftypes.Value{
+		"env": tftypes.NewValue(tftypes.String, "prod"),
+	}))}
+	var diags diag.Diagnostics
+	PlanTagsAll(ctx, tagConfig, &plan, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var tagsAll map[string]string
+	diags.Append(plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)...)
+	if len(tagsAll) != 2 || tagsAll["owner"] != "netops" || tagsAll["env"] != "prod" {
+		t.Errorf("expected the default and own tags, got %v", tagsAll)
+	}
+
+	// tags_all stays unknown while tags are
+	plan = tfsdk.Plan{Schema: testSchema, Raw: testValue("a", tftypes.NewValue(tagsType, tftypes.UnknownValue))}
+	PlanTagsAll(ctx, tagConfig, &plan, &diags)
+	var unknown types.Map
+	diags.Append(plan.GetAttribute(ctx, path.Root("tags_all"), &unknown)...)
+	if diags.HasError() || !unknown.IsUnknown() {
+		t.Errorf("expected tags_all to stay unknown, got %s (%v)", unknown, diags)
+	}
+}
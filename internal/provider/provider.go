@@ -3,7 +3,9 @@ package provider
 import (
 	"context"
 	"os"
+	"time"
 
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/tagging"
 	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -17,16 +19,51 @@ var _ provider.Provider = &BlastshieldProvider{}
 
 type BlastshieldProvider struct {
 	version string
-	vp      versions.VersionedProvider
+	// vp pins the API version. When nil, each provider instance selects the version
+	// of its orchestrator in Configure.
+	vp versions.VersionedProvider
 }
 
 type BlastshieldProviderModel struct {
-	Host  types.String `tfsdk:"host"`
-	Token types.String `tfsdk:"token"`
+	Host                 types.String      `tfsdk:"host"`
+	Token                types.String      `tfsdk:"token"`
+	TokenFile            types.String      `tfsdk:"token_file"`
+	TokenCommand         types.List        `tfsdk:"token_command"`
+	Profile              types.String      `tfsdk:"profile"`
+	MaxRetries           types.Int64       `tfsdk:"max_retries"`
+	RetryMaxWait         types.Int64       `tfsdk:"retry_max_wait"`
+	MaxConcurrent        types.Int64       `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond    types.Float64     `tfsdk:"requests_per_second"`
+	CACertFile           types.String      `tfsdk:"ca_cert_file"`
+	CACertPEM            types.String      `tfsdk:"ca_cert_pem"`
+	ClientCert           types.String      `tfsdk:"client_cert"`
+	ClientKey            types.String      `tfsdk:"client_key"`
+	TLSServerName        types.String      `tfsdk:"tls_server_name"`
+	InsecureSkipVerify   types.Bool        `tfsdk:"insecure_skip_verify"`
+	HTTPProxy            types.String      `tfsdk:"http_proxy"`
+	NoProxy              types.String      `tfsdk:"no_proxy"`
+	CustomHeaders        types.Map         `tfsdk:"custom_headers"`
+	CacheReads           types.Bool        `tfsdk:"cache_reads"`
+	ReadOnly             types.Bool        `tfsdk:"read_only"`
+	APIVersion           types.String      `tfsdk:"api_version"`
+	APIVersionConstraint types.String      `tfsdk:"api_version_constraint"`
+	DefaultTags          *DefaultTagsModel `tfsdk:"default_tags"`
+	IgnoreTags           *IgnoreTagsModel  `tfsdk:"ignore_tags"`
+}
+
+// DefaultTagsModel is the default_tags block of the provider configuration.
+type DefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// IgnoreTagsModel is the ignore_tags block of the provider configuration.
+type IgnoreTagsModel struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
 }
 
 func (p *BlastshieldProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
-	resp.TypeName = "blastshield"
+	resp.TypeName = providerTypeName
 	resp.Version = p.version
 }
 
@@ -43,6 +80,117 @@ func (p *BlastshieldProvider) Schema(ctx context.Context, req provider.SchemaReq
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token_file": schema.StringAttribute{
+				Description: "Path to a file holding the API token. The file is read again whenever it changes and when the API rejects the token, so it can be rotated while Terraform runs. Can also be set via the BLASTSHIELD_TOKEN_FILE environment variable.",
+				Optional:    true,
+			},
+			"token_command": schema.ListAttribute{
+				Description: "Command to run to obtain a short-lived API token, given as the program followed by its arguments. It must print the token, or a JSON object with \"token\" and an RFC 3339 \"expires_at\". The token is cached until it expires, and the command is run again when the API rejects the token. Takes precedence over token and BLASTSHIELD_TOKEN.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the credentials file to take host, token and TLS settings from. Can also be set via the BLASTSHIELD_PROFILE environment variable. Defaults to the \"default\" profile if the file has one. The credentials file is ~/.config/blastshield/credentials unless BLASTSHIELD_CREDENTIALS_FILE is set. Settings in the provider block take precedence over the profile. BLASTSHIELD_* environment variables take precedence over a profile selected through BLASTSHIELD_PROFILE or by default, but only fill the settings a profile named here leaves unset.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times a failed API request is retried. GET, PUT and DELETE requests are retried on connection errors, timeouts, HTTP 429 and HTTP 5xx responses; POST requests only on connection errors. TLS and token errors are not retried. Defaults to 3. Set to 0 to disable retries.",
+				Optional:    true,
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Description: "Maximum number of seconds to wait between retries, including waits requested by a Retry-After header. Defaults to 30.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once, shared by all resources and data sources using this provider configuration. Defaults to 0 (unlimited).",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum sustained rate of API requests per second, shared by all resources and data sources using this provider configuration. Defaults to 0 (unlimited).",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM bundle of CA certificates to trust in addition to the system roots. Can also be set via the BLASTSHIELD_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM bundle of CA certificates to trust in addition to the system roots. Can also be set via the BLASTSHIELD_CA_CERT_PEM environment variable.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "Client certificate for mutual TLS, as PEM content or a path to a PEM file. Requires client_key. Can also be set via the BLASTSHIELD_CLIENT_CERT environment variable.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "Private key for the client certificate, as PEM content or a path to a PEM file. Can also be set via the BLASTSHIELD_CLIENT_KEY environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Server name used to verify the orchestrator certificate, when it differs from the host. Can also be set via the BLASTSHIELD_TLS_SERVER_NAME environment variable.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the orchestrator certificate. Only use this for testing. Can also be set via the BLASTSHIELD_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:    true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the proxy used for all API requests. Can also be set via the BLASTSHIELD_HTTP_PROXY environment variable. When unset, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.",
+				Optional:    true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma-separated hosts, domains and CIDR ranges that bypass http_proxy. Can also be set via the BLASTSHIELD_NO_PROXY environment variable.",
+				Optional:    true,
+			},
+			"cache_reads": schema.BoolAttribute{
+				Description: "Cache API read responses for the duration of a Terraform run, and send identical concurrent reads only once. Cached responses of a collection are discarded whenever the provider writes to that collection. Useful when many data sources read the same collections. Defaults to false.",
+				Optional:    true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "The Blastshield API version to use instead of detecting it from the orchestrator, for example when planning without network access. The newest supported version not above it is selected. Can also be set via the BLASTSHIELD_API_VERSION environment variable. Detected versions are cached per host for an hour in TF_PLUGIN_CACHE_DIR, or the user cache directory.",
+				Optional:    true,
+			},
+			"api_version_constraint": schema.StringAttribute{
+				Description: "Version constraint the orchestrator's API version must meet, such as \">= 1.13, < 1.15\". Configuring the provider fails when it is not met.",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse to create, update or delete anything. Planning a change to a resource fails with an error, and the client rejects every API request other than GET. Data sources are unaffected. Defaults to false.",
+				Optional:    true,
+			},
+			"custom_headers": schema.MapAttribute{
+				Description: "Extra HTTP headers sent with every API request, for example to authenticate with a reverse proxy in front of the orchestrator.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags added to every taggable resource. Tags set on a resource take precedence over default tags with the same key.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Description: "The default tags.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"ignore_tags": schema.SingleNestedBlock{
+				Description: "Tags managed outside of Terraform. Ignored tags are kept on updates and left out of tags and tags_all, in resources and data sources alike.",
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.SetAttribute{
+						Description: "Tag keys to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"key_prefixes": schema.SetAttribute{
+						Description: "Prefixes of tag keys to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -54,9 +202,19 @@ func (p *BlastshieldProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	// Check environment variables
-	host := os.Getenv("BLASTSHIELD_HOST")
-	token := os.Getenv("BLASTSHIELD_TOKEN")
+	// Start from the credentials profile and environment variables
+	conn, err := ConnectionFromEnv(config.Profile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Invalid Credentials Profile",
+			"The provider cannot load the Blastshield credentials profile: "+err.Error(),
+		)
+		return
+	}
+	host := conn.Host
+	token := conn.Token
+	tokenFile := conn.TokenFile
 
 	// Override with config values if set
 	if !config.Host.IsNull() {
@@ -64,6 +222,11 @@ func (p *BlastshieldProvider) Configure(ctx context.Context, req provider.Config
 	}
 	if !config.Token.IsNull() {
 		token = config.Token.ValueString()
+		tokenFile = ""
+	}
+	if !config.TokenFile.IsNull() {
+		tokenFile = config.TokenFile.ValueString()
+		token = ""
 	}
 
 	// Validate required values
@@ -72,45 +235,226 @@ func (p *BlastshieldProvider) Configure(ctx context.Context, req provider.Config
 			path.Root("host"),
 			"Missing Blastshield API Host",
 			"The provider cannot create the Blastshield API client as there is a missing or empty value for the Blastshield API host. "+
-				"Set the host value in the configuration or use the BLASTSHIELD_HOST environment variable.",
+				"Set the host value in the configuration, use the BLASTSHIELD_HOST environment variable or select a credentials profile that sets it.",
+		)
+	}
+
+	var tokenCommand []string
+	if !config.TokenCommand.IsNull() {
+		resp.Diagnostics.Append(config.TokenCommand.ElementsAs(ctx, &tokenCommand, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(tokenCommand) == 0 || tokenCommand[0] == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_command"),
+				"Invalid Token Command",
+				"token_command must name the program to run.",
+			)
+		}
+	}
+
+	tokenSettings := 0
+	for _, set := range []bool{!config.Token.IsNull(), !config.TokenFile.IsNull(), !config.TokenCommand.IsNull()} {
+		if set {
+			tokenSettings++
+		}
+	}
+	if tokenSettings > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting Token Configuration",
+			"Only one of token, token_file and token_command can be set.",
 		)
 	}
 
-	if token == "" {
+	if token == "" && tokenFile == "" && tokenCommand == nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Missing Blastshield API Token",
 			"The provider cannot create the Blastshield API client as there is a missing or empty value for the Blastshield API token. "+
-				"Set the token value in the configuration or use the BLASTSHIELD_TOKEN environment variable.",
+				"Set token, token_file or token_command in the configuration, use the BLASTSHIELD_TOKEN or BLASTSHIELD_TOKEN_FILE environment variable or select a credentials profile that sets one.",
 		)
 	}
 
+	if !config.MaxRetries.IsNull() && config.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Retry Configuration",
+			"max_retries must be zero or greater.",
+		)
+	}
+
+	if !config.RetryMaxWait.IsNull() && config.RetryMaxWait.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Invalid Retry Configuration",
+			"retry_max_wait must be zero or greater.",
+		)
+	}
+
+	if !config.MaxConcurrent.IsNull() && config.MaxConcurrent.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Rate Limit Configuration",
+			"max_concurrent_requests must be zero or greater.",
+		)
+	}
+
+	if !config.RequestsPerSecond.IsNull() && config.RequestsPerSecond.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Rate Limit Configuration",
+			"requests_per_second must be zero or greater.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transport := conn.Transport
+	if !config.CACertFile.IsNull() {
+		transport.CACertFile = config.CACertFile.ValueString()
+	}
+	if !config.CACertPEM.IsNull() {
+		transport.CACertPEM = config.CACertPEM.ValueString()
+	}
+	if !config.ClientCert.IsNull() {
+		transport.ClientCert = config.ClientCert.ValueString()
+	}
+	if !config.ClientKey.IsNull() {
+		transport.ClientKey = config.ClientKey.ValueString()
+	}
+	if !config.TLSServerName.IsNull() {
+		transport.TLSServerName = config.TLSServerName.ValueString()
+	}
+	if !config.InsecureSkipVerify.IsNull() {
+		transport.InsecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+	if !config.HTTPProxy.IsNull() {
+		transport.HTTPProxy = config.HTTPProxy.ValueString()
+	}
+	if !config.NoProxy.IsNull() {
+		transport.NoProxy = config.NoProxy.ValueString()
+	}
+
+	var headers map[string]string
+	if !config.CustomHeaders.IsNull() {
+		resp.Diagnostics.Append(config.CustomHeaders.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tags := &tagging.Config{}
+	if config.DefaultTags != nil && !config.DefaultTags.Tags.IsNull() {
+		resp.Diagnostics.Append(config.DefaultTags.Tags.ElementsAs(ctx, &tags.DefaultTags, false)...)
+	}
+	if config.IgnoreTags != nil {
+		if !config.IgnoreTags.Keys.IsNull() {
+			resp.Diagnostics.Append(config.IgnoreTags.Keys.ElementsAs(ctx, &tags.IgnoreKeys, false)...)
+		}
+		if !config.IgnoreTags.KeyPrefixes.IsNull() {
+			resp.Diagnostics.Append(config.IgnoreTags.KeyPrefixes.ElementsAs(ctx, &tags.IgnoreKeyPrefixes, false)...)
+		}
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	httpClient, err := transport.NewHTTPClient(30 * time.Second)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Transport Configuration",
+			"The provider cannot create the Blastshield API client because the TLS or proxy settings are invalid: "+err.Error(),
+		)
+		return
+	}
+
 	// Create API client
 	client := NewClient(host, token)
+	client.HTTPClient = httpClient
+	var source TokenSource
+	var sourceAttr string
+	switch {
+	case tokenCommand != nil:
+		source, sourceAttr = NewCommandTokenSource(tokenCommand), "token_command"
+	case tokenFile != "":
+		source, sourceAttr = NewFileTokenSource(tokenFile), "token_file"
+	}
+	if source != nil {
+		// Fetch the token now so a broken command or missing file is reported
+		// once, here, rather than by every resource.
+		if _, err := source.Token(ctx); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(sourceAttr),
+				"Unable to Obtain API Token",
+				"The provider cannot obtain a Blastshield API token from "+sourceAttr+": "+err.Error(),
+			)
+			return
+		}
+		client.Token = ""
+		client.TokenSource = source
+	}
+	client.UserAgent = UserAgent(p.version, req.TerraformVersion)
+	client.Headers = headers
+	client.Tags = tags
+	if !config.MaxRetries.IsNull() {
+		client.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		client.RetryMaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
+	}
+	client.SetLimits(int(config.MaxConcurrent.ValueInt64()), config.RequestsPerSecond.ValueFloat64())
+	if config.CacheReads.ValueBool() {
+		client.EnableCache()
+	}
+	client.ReadOnly = config.ReadOnly.ValueBool()
 
-	// Make the client available to resources and data sources
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// A pinned version serves its resources directly. Otherwise this instance binds
+	// the version of its own orchestrator.
+	if p.vp != nil {
+		client.apiVersion = p.vp.Capabilities().APIVersion
+		resp.DataSourceData = client
+		resp.ResourceData = client
+		return
+	}
+	override := os.Getenv("BLASTSHIELD_API_VERSION")
+	if !config.APIVersion.IsNull() {
+		override = config.APIVersion.ValueString()
+	}
+	vp, apiVersion, serverVersion, diags := selectAPIVersion(ctx, client, versionOptions{
+		override:   override,
+		constraint: config.APIVersionConstraint.ValueString(),
+		cache:      defaultVersionCache(),
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client.serverVersion, client.apiVersion = serverVersion, apiVersion
+	bound := bindVersion(client, apiVersion, vp)
+	resp.DataSourceData = bound
+	resp.ResourceData = bound
 }
 
 func (p *BlastshieldProvider) Resources(ctx context.Context) []func() resource.Resource {
 	if p.vp != nil {
 		return p.vp.Resources()
 	}
-	return nil
+	return allResources()
 }
 
 func (p *BlastshieldProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	dataSources := []func() datasource.DataSource{NewAPIInfoDataSource}
 	if p.vp != nil {
-		return p.vp.DataSources()
+		return append(dataSources, p.vp.DataSources()...)
 	}
-	return nil
+	return append(dataSources, allDataSources()...)
 }
 
+// New returns the provider factory. vp pins the API version and is nil unless a
+// test or debugging session needs a specific version.
 func New(version string, vp versions.VersionedProvider) func() provider.Provider {
 	return func() provider.Provider {
 		return &BlastshieldProvider{
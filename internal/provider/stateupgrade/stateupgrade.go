@@ -0,0 +1,226 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stateupgrade carries resource state forward when the package of a newer
+// API version renames or retypes attributes. It is shared by the generated version
+// packages.
+package stateupgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Change lists the attributes a schema version renamed or retyped.
+type Change struct {
+	// Version is the schema version that made the change.
+	Version int64
+	// APIVersion is the API version whose package introduced the schema version.
+	APIVersion string
+	// Renamed maps old attribute names to new ones.
+	Renamed map[string]string
+	// Retyped lists the attributes, by their new name, whose type changed.
+	Retyped []string
+}
+
+// Versioned is implemented by resources whose schema changed in a later API version.
+type Versioned interface {
+	// SchemaChanges returns the changes ordered by Version.
+	SchemaChanges() []Change
+}
+
+// SchemaVersion returns the schema version reached by changes.
+func SchemaVersion(changes []Change) int64 {
+	if len(changes) == 0 {
+		return 0
+	}
+	return changes[len(changes)-1].Version
+}
+
+// Upgraders returns a state upgrader from every schema version before the current
+// version of schema. changes must be ordered by Version.
+func Upgraders(ctx context.Context, schema resourceschema.Schema, changes []Change) map[int64]resource.StateUpgrader {
+	typ, ok := schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		return nil
+	}
+
+	upgraders := make(map[int64]resource.StateUpgrader, schema.Version)
+	for prior := int64(0); prior < schema.Version; prior++ {
+		upgraders[prior] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("State of schema version %d has no JSON form to upgrade.", prior),
+					)
+					return
+				}
+				state, err := Upgrade(req.RawState.JSON, prior, changes, typ)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Upgrading state from schema version %d to %d: %s", prior, schema.Version, err),
+					)
+					return
+				}
+				resp.State.Raw = state
+			},
+		}
+	}
+	return upgraders
+}
+
+// Upgrade applies the changes made after schema version prior to a state in JSON
+// form and decodes it as typ. Attributes that no longer exist are dropped, and new
+// ones are null. A retyped value that cannot be converted is null as well; the
+// next read refreshes it from the API.
+func Upgrade(state []byte, prior int64, changes []Change, typ tftypes.Object) (tftypes.Value, error) {
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(state, &attrs); err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding state: %w", err)
+	}
+
+	retyped := map[string]bool{}
+	for _, c := range changes {
+		if c.Version <= prior {
+			continue
+		}
+		renamedRetyped := map[string]bool{}
+		for name := range retyped {
+			if newName, ok := c.Renamed[name]; ok {
+				name = newName
+			}
+			renamedRetyped[name] = true
+		}
+		retyped = renamedRetyped
+
+		moved := map[string]json.RawMessage{}
+		for oldName, newName := range c.Renamed {
+			if v, ok := attrs[oldName]; ok {
+				moved[newName] = v
+				delete(attrs, oldName)
+			}
+		}
+		for name, v := range moved {
+			attrs[name] = v
+		}
+		for _, name := range c.Retyped {
+			retyped[name] = true
+		}
+	}
+
+	for name := range retyped {
+		raw, ok := attrs[name]
+		attrType, defined := typ.AttributeTypes[name]
+		if !ok || !defined {
+			continue
+		}
+		if converted, ok := convert(raw, attrType); ok {
+			attrs[name] = converted
+		} else {
+			delete(attrs, name)
+		}
+	}
+
+	upgraded, err := json.Marshal(attrs)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	return tftypes.ValueFromJSONWithOpts(upgraded, typ, tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+}
+
+// convert returns raw as a JSON value of typ. Scalars are converted through their
+// string form, a scalar becomes a one-element list or set, and a one-element list
+// or set becomes its element.
+func convert(raw json.RawMessage, typ tftypes.Type) (json.RawMessage, bool) {
+	if fits(raw, typ) {
+		return raw, true
+	}
+
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	var elemType tftypes.Type
+	switch t := typ.(type) {
+	case tftypes.List:
+		elemType = t.ElementType
+	case tftypes.Set:
+		elemType = t.ElementType
+	}
+
+	var converted interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		if elemType != nil || len(v) != 1 {
+			return nil, false
+		}
+		elem, err := json.Marshal(v[0])
+		if err != nil {
+			return nil, false
+		}
+		return convert(elem, typ)
+	case map[string]interface{}:
+		return nil, false
+	default:
+		if elemType != nil {
+			elem, ok := convert(raw, elemType)
+			if !ok {
+				return nil, false
+			}
+			return json.RawMessage("[" + string(elem) + "]"), true
+		}
+		s := fmt.Sprint(v)
+		switch {
+		case typ.Is(tftypes.String):
+			converted = s
+		case typ.Is(tftypes.Number):
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return nil, false
+			}
+			converted = json.Number(s)
+		case typ.Is(tftypes.Bool):
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, false
+			}
+			converted = b
+		default:
+			return nil, false
+		}
+	}
+
+	out, err := json.Marshal(converted)
+	if err != nil || !fits(out, typ) {
+		return nil, false
+	}
+	return out, true
+}
+
+// fits reports whether raw decodes as typ.
+func fits(raw json.RawMessage, typ tftypes.Type) bool {
+	_, err := tftypes.ValueFromJSONWithOpts(raw, typ, tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+	return err == nil
+}
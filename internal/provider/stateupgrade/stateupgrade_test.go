@@ -0,0 +1,121 @@
+package stateupgrade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"id":       tftypes.Number,
+	"hostname": tftypes.String,
+	"port":     tftypes.String,
+	"expires":  tftypes.Number,
+	"dns_name": tftypes.Set{ElementType: tftypes.String},
+}}
+
+func TestUpgrade_RenamesAndRetypes(t *testing.T) {
+	changes := []Change{
+		{Version: 1, APIVersion: "1.14.0", Renamed: map[string]string{"name": "hostname"}, Retyped: []string{"port"}},
+		{Version: 2, APIVersion: "1.15.0", Retyped: []string{"expires", "dns_name"}},
+	}
+	state := []byte(`{"id": 7, "name": "gw", "port": 443, "expires": "1700000000", "dns_name": "gw.example.com", "removed": true}`)
+
+	got, err := Upgrade(state, 0, changes, testType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := tftypes.NewValue(testType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.Number, 7),
+		"hostname": tftypes.NewValue(tftypes.String, "gw"),
+		"port":     tftypes.NewValue(tftypes.String, "443"),
+		"expires":  tftypes.NewValue(tftypes.Number, 1700000000),
+		"dns_name": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "gw.example.com"),
+		}),
+	})
+	if !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestUpgrade_SkipsChangesUpToPrior(t *testing.T) {
+	changes := []Change{
+		{Version: 1, Renamed: map[string]string{"name": "hostname"}},
+	}
+	// State of version 1 already uses the new name
+	got, err := Upgrade([]byte(`{"id": 7, "hostname": "gw", "name": "stale"}`), 1, changes, testType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := got.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["hostname"].Equal(tftypes.NewValue(tftypes.String, "gw")) {
+		t.Errorf("expected hostname to be kept, got %s", attrs["hostname"])
+	}
+}
+
+func TestUpgrade_UnconvertibleValueIsNull(t *testing.T) {
+	changes := []Change{{Version: 1, Retyped: []string{"expires"}}}
+
+	got, err := Upgrade([]byte(`{"id": 7, "expires": "never"}`), 0, changes, testType)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := got.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	if !attrs["expires"].IsNull() {
+		t.Errorf("expected expires to be null, got %s", attrs["expires"])
+	}
+}
+
+func TestUpgraders(t *testing.T) {
+	ctx := context.Background()
+	schema := resourceschema.Schema{
+		Version: 2,
+		Attributes: map[string]resourceschema.Attribute{
+			"id":       resourceschema.Int64Attribute{Computed: true},
+			"hostname": resourceschema.StringAttribute{Required: true},
+			"tags":     resourceschema.MapAttribute{ElementType: types.StringType, Optional: true},
+		},
+	}
+	changes := []Change{
+		{Version: 1, Renamed: map[string]string{"name": "hostname"}},
+		{Version: 2, Retyped: []string{"tags"}},
+	}
+
+	upgraders := Upgraders(ctx, schema, changes)
+	if len(upgraders) != 2 {
+		t.Fatalf("expected upgraders from versions 0 and 1, got %d", len(upgraders))
+	}
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schema}}
+	upgraders[0].StateUpgrader(ctx, resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"id": 7, "name": "gw", "tags": ["a"]}`)},
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var hostname types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("hostname"), &hostname)...)
+	if hostname.ValueString() != "gw" {
+		t.Errorf("expected hostname gw, got %s", hostname)
+	}
+	var tags types.Map
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if !tags.IsNull() {
+		t.Errorf("expected unconvertible tags to be null, got %s", tags)
+	}
+}
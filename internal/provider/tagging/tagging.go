@@ -0,0 +1,138 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tagging implements the provider-level default_tags and ignore_tags
+// settings. It is shared by the provider package and the generated version packages.
+package tagging
+
+import "strings"
+
+// Config holds the tag settings of a provider instance. A nil *Config has no
+// default tags and ignores nothing.
+type Config struct {
+	// DefaultTags are added to every taggable resource. A resource's own tags
+	// take precedence over default tags with the same key.
+	DefaultTags map[string]string
+	// IgnoreKeys are tag keys managed outside of Terraform.
+	IgnoreKeys []string
+	// IgnoreKeyPrefixes are prefixes of tag keys managed outside of Terraform.
+	IgnoreKeyPrefixes []string
+}
+
+// Ignored reports whether the tag key is managed outside of Terraform.
+func (c *Config) Ignored(key string) bool {
+	if c == nil {
+		return false
+	}
+	for _, k := range c.IgnoreKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, p := range c.IgnoreKeyPrefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIgnores reports whether any tags are ignored.
+func (c *Config) HasIgnores() bool {
+	return c != nil && (len(c.IgnoreKeys) > 0 || len(c.IgnoreKeyPrefixes) > 0)
+}
+
+// Merge returns the tags to send to the API for a resource whose own tags are
+// resourceTags: the default tags, overridden by the resource's tags, plus the
+// ignored tags found in current so that an update does not remove them. priorAll
+// is the resource's tags_all in its prior state, nil on create. It returns nil,
+// meaning tags should not be sent, when neither the plan nor the prior state has
+// tags; otherwise the result may be empty, so that tags which are no longer
+// configured, such as removed default tags, are cleared.
+func (c *Config) Merge(resourceTags, priorAll, current map[string]string) map[string]string {
+	var defaults map[string]string
+	if c != nil {
+		defaults = c.DefaultTags
+	}
+	if resourceTags == nil && len(defaults) == 0 && len(priorAll) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]string, len(defaults)+len(resourceTags))
+	for k, v := range current {
+		if c.Ignored(k) {
+			merged[k] = v
+		}
+	}
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range resourceTags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// All returns the expected tags_all of a resource with the given tags: the
+// default tags overridden by the resource's tags, without ignored keys.
+func (c *Config) All(resourceTags map[string]string) map[string]string {
+	all := map[string]string{}
+	for k, v := range c.Merge(resourceTags, nil, nil) {
+		if !c.Ignored(k) {
+			all[k] = v
+		}
+	}
+	return all
+}
+
+// Split divides the tags returned by the API into the resource's own tags and
+// tags_all, leaving out ignored keys. A tag belongs to the resource unless it
+// matches a default tag, or if it is one of the configured tags, which are the
+// resource's tags from its plan or prior state.
+func (c *Config) Split(apiTags, configured map[string]string) (own, all map[string]string) {
+	own = map[string]string{}
+	all = map[string]string{}
+	for k, v := range apiTags {
+		if c.Ignored(k) {
+			continue
+		}
+		all[k] = v
+
+		_, isConfigured := configured[k]
+		defaultValue, isDefault := "", false
+		if c != nil {
+			defaultValue, isDefault = c.DefaultTags[k]
+		}
+		if isConfigured || !isDefault || defaultValue != v {
+			own[k] = v
+		}
+	}
+	return own, all
+}
+
+// Visible returns the tags returned by the API without ignored keys. Data sources
+// report them as both tags and tags_all, as default_tags only apply to resources.
+// A nil map stays nil.
+func (c *Config) Visible(apiTags map[string]string) map[string]string {
+	if apiTags == nil {
+		return nil
+	}
+	visible := make(map[string]string, len(apiTags))
+	for k, v := range apiTags {
+		if !c.Ignored(k) {
+			visible[k] = v
+		}
+	}
+	return visible
+}
@@ -0,0 +1,120 @@
+package tagging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testConfig() *Config {
+	return &Config{
+		DefaultTags:       map[string]string{"owner": "netops", "cost_center": "42"},
+		IgnoreKeys:        []string{"scanner"},
+		IgnoreKeyPrefixes: []string{"ext:"},
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	c := testConfig()
+
+	for key, want := range map[string]bool{"scanner": true, "ext:id": true, "owner": false, "scanner2": false} {
+		if got := c.Ignored(key); got != want {
+			t.Errorf("Ignored(%q) = %v, want %v", key, got, want)
+		}
+	}
+
+	var nilConfig *Config
+	if nilConfig.Ignored("scanner") || nilConfig.HasIgnores() {
+		t.Error("expected a nil config to ignore nothing")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	c := testConfig()
+
+	got := c.Merge(
+		map[string]string{"owner": "appteam", "env": "prod"},
+		nil,
+		map[string]string{"scanner": "ok", "ext:id": "7", "stale": "x"},
+	)
+	want := map[string]string{"owner": "appteam", "cost_center": "42", "env": "prod", "scanner": "ok", "ext:id": "7"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+}
+
+func TestMerge_NothingToSend(t *testing.T) {
+	var nilConfig *Config
+	if got := nilConfig.Merge(nil, nil, map[string]string{"a": "b"}); got != nil {
+		t.Errorf("expected nil without tags or defaults, got %v", got)
+	}
+	if got := nilConfig.Merge(map[string]string{}, nil, nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty map to clear tags, got %v", got)
+	}
+}
+
+// Removing default_tags clears the default tags a resource without tags of its
+// own was created with, keeping ignored tags.
+func TestMerge_RemovedDefaultTags(t *testing.T) {
+	c := &Config{IgnoreKeys: []string{"scanner"}}
+
+	got := c.Merge(nil, map[string]string{"owner": "netops"}, map[string]string{"owner": "netops", "scanner": "ok"})
+	want := map[string]string{"scanner": "ok"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+
+	var nilConfig *Config
+	if got := nilConfig.Merge(nil, map[string]string{"owner": "netops"}, nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty map to clear the default tags, got %v", got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	c := testConfig()
+
+	got := c.All(map[string]string{"env": "prod", "ext:note": "x"})
+	want := map[string]string{"owner": "netops", "cost_center": "42", "env": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	c := testConfig()
+	apiTags := map[string]string{
+		"owner":       "netops",
+		"cost_center": "99",
+		"env":         "prod",
+		"scanner":     "ok",
+		"ext:id":      "7",
+	}
+
+	own, all := c.Split(apiTags, nil)
+	wantOwn := map[string]string{"cost_center": "99", "env": "prod"}
+	wantAll := map[string]string{"owner": "netops", "cost_center": "99", "env": "prod"}
+	if !reflect.DeepEqual(own, wantOwn) {
+		t.Errorf("own = %v, want %v", own, wantOwn)
+	}
+	if !reflect.DeepEqual(all, wantAll) {
+		t.Errorf("all = %v, want %v", all, wantAll)
+	}
+
+	// A configured tag that repeats a default tag stays in the resource's tags
+	own, _ = c.Split(apiTags, map[string]string{"owner": "netops"})
+	if own["owner"] != "netops" {
+		t.Errorf("expected configured owner tag to be kept, got %v", own)
+	}
+}
+
+func TestVisible(t *testing.T) {
+	c := testConfig()
+
+	got := c.Visible(map[string]string{"owner": "netops", "scanner": "ok", "ext:id": "7"})
+	want := map[string]string{"owner": "netops"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Visible() = %v, want %v", got, want)
+	}
+	if got := c.Visible(nil); got != nil {
+		t.Errorf("expected nil tags to stay nil, got %v", got)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies API tokens that can change while the provider runs.
+type TokenSource interface {
+	// Token returns the token to use for the next request.
+	Token(ctx context.Context) (string, error)
+	// Invalidate reports that the API rejected token. The next call to Token
+	// returns a fresh token unless another one was obtained in the meantime.
+	Invalidate(token string)
+}
+
+// tokenExpiryMargin is how long before its expiry a token is considered stale, so
+// that it does not expire between being fetched and reaching the server.
+const tokenExpiryMargin = 30 * time.Second
+
+// CommandTokenSource obtains tokens by running an external command. The command
+// prints either the token itself or a JSON object {"token": "...", "expires_at": "..."}
+// with an RFC 3339 expiry. Tokens are cached until they expire or are rejected.
+type CommandTokenSource struct {
+	// Command is the program and its arguments. It is run without a shell.
+	Command []string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewCommandTokenSource returns a token source that runs command.
+func NewCommandTokenSource(command []string) *CommandTokenSource {
+	return &CommandTokenSource{Command: command}
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiresAt.IsZero() || time.Now().Add(tokenExpiryMargin).Before(s.expiresAt)) {
+		return s.token, nil
+	}
+
+	token, expiresAt, err := s.run(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiresAt = expiresAt
+	return token, nil
+}
+
+func (s *CommandTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+		s.expiresAt = time.Time{}
+	}
+}
+
+func (s *CommandTokenSource) run(ctx context.Context) (string, time.Time, error) {
+	if len(s.Command) == 0 {
+		return "", time.Time{}, errors.New("token command is empty")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", time.Time{}, fmt.Errorf("running token command %q: %w: %s", s.Command[0], err, msg)
+		}
+		return "", time.Time{}, fmt.Errorf("running token command %q: %w", s.Command[0], err)
+	}
+
+	return parseTokenOutput(stdout.Bytes())
+}
+
+// parseTokenOutput parses the output of a token command.
+func parseTokenOutput(output []byte) (string, time.Time, error) {
+	output = bytes.TrimSpace(output)
+
+	if len(output) > 0 && output[0] == '{' {
+		var result struct {
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			return "", time.Time{}, fmt.Errorf("parsing token command output: %w", err)
+		}
+		if result.Token == "" {
+			return "", time.Time{}, errors.New("token command output has no token")
+		}
+		return result.Token, result.ExpiresAt, nil
+	}
+
+	if len(output) == 0 {
+		return "", time.Time{}, errors.New("token command printed no token")
+	}
+	return string(output), time.Time{}, nil
+}
+
+// FileTokenSource reads the token from a file, such as a Kubernetes projected
+// secret. The file is read again whenever its modification time changes or the
+// API rejects the token, so rotating the file takes effect without a restart.
+type FileTokenSource struct {
+	Path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+// NewFileTokenSource returns a token source that reads path.
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{Path: path}
+}
+
+func (s *FileTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return "", fmt.Errorf("reading token file: %w", err)
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	token, err := readTokenFile(s.Path)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.modTime = info.ModTime()
+	return token, nil
+}
+
+func (s *FileTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// readTokenFile returns the contents of a token file without surrounding whitespace.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
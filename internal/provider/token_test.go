@@ -0,0 +1,224 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/apierror"
+)
+
+// countingCommand returns a token command that prints "token-<n>" on its n-th run.
+func countingCommand(t *testing.T) []string {
+	t.Helper()
+	counter := filepath.Join(t.TempDir(), "runs")
+	return []string{"sh", "-c", `echo run >> "$0"; echo "token-$(wc -l < "$0" | tr -d ' ')"`, counter}
+}
+
+func TestParseTokenOutput(t *testing.T) {
+	token, expiresAt, err := parseTokenOutput([]byte("  plain-token\n"))
+	if err != nil || token != "plain-token" || !expiresAt.IsZero() {
+		t.Errorf("unexpected result for plain output: %q, %v, %v", token, expiresAt, err)
+	}
+
+	token, expiresAt, err = parseTokenOutput([]byte(`{"token": "json-token", "expires_at": "2030-01-02T03:04:05Z"}`))
+	if err != nil || token != "json-token" || !expiresAt.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected result for JSON output: %q, %v, %v", token, expiresAt, err)
+	}
+
+	for _, output := range []string{"", "\n", `{"expires_at": "2030-01-02T03:04:05Z"}`, `{"token": `} {
+		if _, _, err := parseTokenOutput([]byte(output)); err == nil {
+			t.Errorf("expected an error for output %q", output)
+		}
+	}
+}
+
+func TestCommandTokenSource_CachesAndInvalidates(t *testing.T) {
+	source := NewCommandTokenSource(countingCommand(t))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		token, err := source.Token(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != "token-1" {
+			t.Fatalf("expected the cached token, got %q", token)
+		}
+	}
+
+	// Invalidating a token that was already replaced keeps the current one
+	source.Invalidate("token-0")
+	if token, _ := source.Token(ctx); token != "token-1" {
+		t.Errorf("expected token-1 to be kept, got %q", token)
+	}
+
+	source.Invalidate("token-1")
+	if token, _ := source.Token(ctx); token != "token-2" {
+		t.Errorf("expected the command to run again, got %q", token)
+	}
+}
+
+func TestCommandTokenSource_RefreshesExpiredTokens(t *testing.T) {
+	expired := time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)
+	counter := filepath.Join(t.TempDir(), "runs")
+	source := NewCommandTokenSource([]string{"sh", "-c",
+		`echo run >> "$0"; echo "{\"token\": \"token-$(wc -l < "$0" | tr -d ' ')\", \"expires_at\": \"$1\"}"`,
+		counter, expired,
+	})
+	ctx := context.Background()
+
+	first, err := source.Token(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := source.Token(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second {
+		t.Errorf("expected a token within the expiry margin to be refreshed, got %q twice", first)
+	}
+}
+
+func TestCommandTokenSource_CommandFails(t *testing.T) {
+	source := NewCommandTokenSource([]string{"sh", "-c", "echo 'not logged in' >&2; exit 3"})
+
+	_, err := source.Token(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "not logged in") {
+		t.Errorf("expected stderr in the error, got %v", err)
+	}
+}
+
+func TestClient_RefreshesTokenOnUnauthorized(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail": "Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	client.TokenSource = NewCommandTokenSource(countingCommand(t))
+	client.MaxRetries = 0
+
+	var result map[string]interface{}
+	if err := client.Read(context.Background(), "/nodes/1", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestClient_RefreshesTokenOnlyOnce(t *testing.T) {
+	var calls atomic.Int32
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	client.TokenSource = NewCommandTokenSource(countingCommand(t))
+
+	var result map[string]interface{}
+	err := client.Read(context.Background(), "/nodes/1", &result)
+	if !errors.Is(err, apierror.ErrUnauthorized) {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestFileTokenSource_ReloadsChangedFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("token-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := NewFileTokenSource(file)
+	ctx := context.Background()
+
+	if token, err := source.Token(ctx); err != nil || token != "token-1" {
+		t.Fatalf("unexpected token %q, %v", token, err)
+	}
+
+	if err := os.WriteFile(file, []byte("token-2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change is visible even on filesystems with coarse timestamps
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := source.Token(ctx); err != nil || token != "token-2" {
+		t.Errorf("expected the rotated token, got %q, %v", token, err)
+	}
+}
+
+func TestFileTokenSource_RereadsAfterInvalidate(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("token-1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := NewFileTokenSource(file)
+	ctx := context.Background()
+
+	if token, _ := source.Token(ctx); token != "token-1" {
+		t.Fatalf("unexpected token %q", token)
+	}
+
+	// Rewrite the file but keep its modification time, as some secret mounts do
+	if err := os.WriteFile(file, []byte("token-2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := source.Token(ctx); token != "token-1" {
+		t.Fatalf("expected the cached token before a 401, got %q", token)
+	}
+
+	source.Invalidate("token-1")
+	if token, _ := source.Token(ctx); token != "token-2" {
+		t.Errorf("expected the file to be read again, got %q", token)
+	}
+}
+
+func TestFileTokenSource_EmptyFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileTokenSource(file).Token(context.Background()); err == nil {
+		t.Error("expected an error for an empty token file")
+	}
+}
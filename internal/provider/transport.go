@@ -0,0 +1,192 @@
+// Copyright 2026 BlastWave, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// TransportConfig describes how to connect to the Blastshield API. Configure reads
+// it from the environment and credentials profile and applies the provider block.
+type TransportConfig struct {
+	// CACertFile is a path to a PEM bundle of additional trusted CAs.
+	CACertFile string
+	// CACertPEM is a PEM bundle of additional trusted CAs.
+	CACertPEM string
+	// ClientCert and ClientKey enable mutual TLS. Each is PEM content or a path to a PEM file.
+	ClientCert string
+	ClientKey  string
+	// TLSServerName overrides the name used to verify the server certificate.
+	TLSServerName string
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool
+	// HTTPProxy is the proxy URL used for all requests. When empty the standard
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+	HTTPProxy string
+	// NoProxy lists hosts that bypass HTTPProxy, in NO_PROXY format.
+	NoProxy string
+}
+
+// TransportConfigFromEnv reads the BLASTSHIELD_* connection environment variables.
+func TransportConfigFromEnv() TransportConfig {
+	insecure, _ := strconv.ParseBool(os.Getenv("BLASTSHIELD_INSECURE_SKIP_VERIFY"))
+	return TransportConfig{
+		CACertFile:         os.Getenv("BLASTSHIELD_CA_CERT_FILE"),
+		CACertPEM:          os.Getenv("BLASTSHIELD_CA_CERT_PEM"),
+		ClientCert:         os.Getenv("BLASTSHIELD_CLIENT_CERT"),
+		ClientKey:          os.Getenv("BLASTSHIELD_CLIENT_KEY"),
+		TLSServerName:      os.Getenv("BLASTSHIELD_TLS_SERVER_NAME"),
+		InsecureSkipVerify: insecure,
+		HTTPProxy:          os.Getenv("BLASTSHIELD_HTTP_PROXY"),
+		NoProxy:            os.Getenv("BLASTSHIELD_NO_PROXY"),
+	}
+}
+
+// Merge returns c with every setting that is set in o replaced by the value from o.
+func (c TransportConfig) Merge(o TransportConfig) TransportConfig {
+	if o.CACertFile != "" {
+		c.CACertFile = o.CACertFile
+	}
+	if o.CACertPEM != "" {
+		c.CACertPEM = o.CACertPEM
+	}
+	if o.ClientCert != "" {
+		c.ClientCert = o.ClientCert
+	}
+	if o.ClientKey != "" {
+		c.ClientKey = o.ClientKey
+	}
+	if o.TLSServerName != "" {
+		c.TLSServerName = o.TLSServerName
+	}
+	if o.InsecureSkipVerify {
+		c.InsecureSkipVerify = true
+	}
+	if o.HTTPProxy != "" {
+		c.HTTPProxy = o.HTTPProxy
+	}
+	if o.NoProxy != "" {
+		c.NoProxy = o.NoProxy
+	}
+	return c
+}
+
+// NewHTTPClient returns an HTTP client that applies the configuration.
+func (c TransportConfig) NewHTTPClient(timeout time.Duration) (*http.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if c.HTTPProxy != "" {
+		if _, err := url.Parse(c.HTTPProxy); err != nil {
+			return nil, fmt.Errorf("parsing HTTP proxy URL: %w", err)
+		}
+		proxy := (&httpproxy.Config{
+			HTTPProxy:  c.HTTPProxy,
+			HTTPSProxy: c.HTTPProxy,
+			NoProxy:    c.NoProxy,
+		}).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxy(req.URL)
+		}
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+func (c TransportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.TLSServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CACertFile != "" || c.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if c.CACertFile != "" {
+			pem, err := os.ReadFile(c.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no PEM certificates found in %s", c.CACertFile)
+			}
+		}
+		if c.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+			return nil, fmt.Errorf("no PEM certificates found in CA certificate PEM")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+		certPEM, err := pemOrFile(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("reading client certificate: %w", err)
+		}
+		keyPEM, err := pemOrFile(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// pemOrFile returns value itself when it holds PEM data, otherwise the contents of
+// the file it names.
+func pemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// UserAgent returns the User-Agent sent with every API request. The Terraform part
+// is left out when the Terraform version is not known.
+func UserAgent(providerVersion, terraformVersion string) string {
+	ua := "terraform-provider-blastshield/" + providerVersion
+	if terraformVersion != "" {
+		ua += " terraform/" + terraformVersion
+	}
+	return ua
+}
@@ -0,0 +1,62 @@
+package versions
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CapabilitySet describes what an API version supports, so behavior can be gated
+// on the version a server runs.
+type CapabilitySet struct {
+	// APIVersion is the version of the OpenAPI document.
+	APIVersion string
+	// SpecHash is the hex SHA-256 of the OpenAPI document.
+	SpecHash string
+	// Resources maps resource names, the Terraform type names without the provider
+	// prefix such as egress_policy, to their capabilities.
+	Resources map[string]ResourceCapabilities
+}
+
+// ResourceCapabilities describes what an API version supports for a resource.
+type ResourceCapabilities struct {
+	// Attributes are the attribute names of the resource schema, sorted.
+	Attributes []string
+	// SubEndpoints are the paths below an entity, such as /groups and /tags, sorted.
+	SubEndpoints []string
+}
+
+// HasResource reports whether the version supports the resource.
+func (c CapabilitySet) HasResource(name string) bool {
+	_, ok := c.Resources[name]
+	return ok
+}
+
+// HasAttribute reports whether the version supports the attribute of a resource.
+func (c CapabilitySet) HasAttribute(resource, attribute string) bool {
+	return contains(c.Resources[resource].Attributes, attribute)
+}
+
+// HasSubEndpoint reports whether the version supports the sub-endpoint of a
+// resource, given with its leading slash.
+func (c CapabilitySet) HasSubEndpoint(resource, endpoint string) bool {
+	return contains(c.Resources[resource].SubEndpoints, endpoint)
+}
+
+// Unsupported returns the error for a feature the version does not support.
+func (c CapabilitySet) Unsupported(feature string) error {
+	return fmt.Errorf("%s is not supported by Blastshield API version %s", feature, c.APIVersion)
+}
+
+func contains(sorted []string, s string) bool {
+	i := sort.SearchStrings(sorted, s)
+	return i < len(sorted) && sorted[i] == s
+}
+
+// Capabilities returns the capabilities of the provider registered for exactly ver.
+func Capabilities(ver string) (CapabilitySet, bool) {
+	vp, ok := Lookup(ver)
+	if !ok {
+		return CapabilitySet{}, false
+	}
+	return vp.Capabilities(), true
+}
@@ -14,6 +14,7 @@ import (
 type VersionedProvider interface {
 	Resources() []func() resource.Resource
 	DataSources() []func() datasource.DataSource
+	Capabilities() CapabilitySet
 }
 
 var (
@@ -89,6 +90,32 @@ func LatestVersion() (VersionedProvider, string) {
 	return registry[bestKey], bestKey
 }
 
+// Versions returns the registered versions, lowest first.
+func Versions() []string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	keys := registeredVersions()
+	sort.SliceStable(keys, func(i, j int) bool {
+		vi, erri := version.NewVersion(keys[i])
+		vj, errj := version.NewVersion(keys[j])
+		if erri != nil || errj != nil {
+			return keys[i] < keys[j]
+		}
+		return vi.LessThan(vj)
+	})
+	return keys
+}
+
+// Lookup returns the provider registered for exactly ver.
+func Lookup(ver string) (VersionedProvider, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	vp, ok := registry[ver]
+	return vp, ok
+}
+
 func registeredVersions() []string {
 	versions := make([]string, 0, len(registry))
 	for key := range registry {
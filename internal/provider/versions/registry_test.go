@@ -13,6 +13,14 @@ type mockVersionedProvider struct {
 
 func (m *mockVersionedProvider) Resources() []func() resource.Resource     { return nil }
 func (m *mockVersionedProvider) DataSources() []func() datasource.DataSource { return nil }
+func (m *mockVersionedProvider) Capabilities() CapabilitySet {
+	return CapabilitySet{
+		APIVersion: m.version,
+		Resources: map[string]ResourceCapabilities{
+			"node": {Attributes: []string{"id", "name"}, SubEndpoints: []string{"/groups", "/tags"}},
+		},
+	}
+}
 
 func resetRegistry() {
 	mu.Lock()
@@ -115,3 +123,59 @@ func TestLatestVersion_Empty(t *testing.T) {
 		t.Fatal("expected nil provider")
 	}
 }
+
+func TestVersions_SortedBySemver(t *testing.T) {
+	resetRegistry()
+	Register("1.13.0", &mockVersionedProvider{version: "1.13.0"})
+	Register("1.9.0", &mockVersionedProvider{version: "1.9.0"})
+	Register("1.14.0", &mockVersionedProvider{version: "1.14.0"})
+
+	got := Versions()
+	want := []string{"1.9.0", "1.13.0", "1.14.0"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	resetRegistry()
+	Register("1.13.0", &mockVersionedProvider{version: "1.13.0"})
+
+	if vp, ok := Lookup("1.13.0"); !ok || vp == nil {
+		t.Error("expected 1.13.0 to be registered")
+	}
+	if _, ok := Lookup("1.14.0"); ok {
+		t.Error("did not expect 1.14.0 to be registered")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	resetRegistry()
+	Register("1.13.0", &mockVersionedProvider{version: "1.13.0"})
+
+	caps, ok := Capabilities("1.13.0")
+	if !ok {
+		t.Fatal("expected capabilities for 1.13.0")
+	}
+	if !caps.HasResource("node") || caps.HasResource("proxy") {
+		t.Error("expected node and not proxy to be supported")
+	}
+	if !caps.HasAttribute("node", "name") || caps.HasAttribute("node", "settings") || caps.HasAttribute("proxy", "name") {
+		t.Error("expected only the node attributes to be supported")
+	}
+	if !caps.HasSubEndpoint("node", "/groups") || caps.HasSubEndpoint("node", "/users") {
+		t.Error("expected /groups and not /users to be supported")
+	}
+	if err := caps.Unsupported("blastshield_proxy"); err == nil || err.Error() != "blastshield_proxy is not supported by Blastshield API version 1.13.0" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, ok := Capabilities("1.14.0"); ok {
+		t.Error("did not expect capabilities for 1.14.0")
+	}
+}
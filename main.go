@@ -16,17 +16,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
-	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"os"
-	"time"
 
 	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider"
-	"github.com/blastwaveinc/terraform-provider-blastshield/internal/provider/versions"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	// Import all version packages so their init() functions register them.
@@ -48,84 +41,16 @@ func main() {
 		Debug:   debug,
 	}
 
-	// Detect API version before starting the provider server.
-	// Resources()/DataSources() are called before Configure(), so we need
-	// to select the right version package upfront.
-	vp := detectVersion()
-
-	err := providerserver.Serve(context.Background(), provider.New(version, vp), opts)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-}
-
-func detectVersion() versions.VersionedProvider {
-	host := os.Getenv("BLASTSHIELD_HOST")
-	token := os.Getenv("BLASTSHIELD_TOKEN")
-
-	if host != "" && token != "" {
-		serverVersion, err := fetchAPIVersion(host, token)
-		if err != nil {
-			log.Printf("[WARN] Could not fetch API version from %s: %v, using latest compiled version", host, err)
-			vp, ver := versions.LatestVersion()
-			if vp != nil {
-				log.Printf("[INFO] Using latest API version: %s", ver)
-			}
-			return vp
-		}
-
-		vp, ver, err := versions.SelectVersion(serverVersion)
-		if err != nil {
-			log.Fatalf("[ERROR] API version %s not supported: %v", serverVersion, err)
-		}
-		log.Printf("[INFO] Server API version: %s, using provider version: %s", serverVersion, ver)
-		return vp
-	}
-
-	// No credentials available (e.g., terraform validate) — use latest version
-	vp, ver := versions.LatestVersion()
-	if vp != nil {
-		log.Printf("[INFO] No BLASTSHIELD_HOST/TOKEN set, using latest API version: %s", ver)
+	ctx := context.Background()
+	if ver, err := provider.RegisterDynamicVersion(ctx, version); err != nil {
+		log.Printf("[WARN] Not using the dynamic API schema: %s", err)
+	} else if ver != "" {
+		log.Printf("[INFO] Serving API version %s from the orchestrator's OpenAPI document", ver)
 	}
-	return vp
-}
 
-func fetchAPIVersion(host, token string) (string, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	req, err := http.NewRequest("GET", host+"/openapi.json", nil)
+	// Each provider instance selects the API version of its orchestrator in Configure
+	err := providerserver.Serve(ctx, provider.New(version, nil), opts)
 	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("fetching openapi.json: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("openapi.json returned status %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading response: %w", err)
-	}
-
-	var spec struct {
-		Info struct {
-			Version string `json:"version"`
-		} `json:"info"`
-	}
-	if err := json.Unmarshal(body, &spec); err != nil {
-		return "", fmt.Errorf("parsing openapi.json: %w", err)
-	}
-
-	if spec.Info.Version == "" {
-		return "", fmt.Errorf("openapi.json missing info.version")
+		log.Fatal(err.Error())
 	}
-
-	return spec.Info.Version, nil
 }